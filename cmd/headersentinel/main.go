@@ -5,12 +5,14 @@ import (
 	"flag"
 	"fmt"
 	"net/http"
+	neturl "net/url"
 	"os"
 	"strings"
 	"sync"
 	"time"
 
 	"github.com/ismailtsdln/HeaderSentinel/internal/report"
+	"github.com/ismailtsdln/HeaderSentinel/internal/rules"
 	"github.com/ismailtsdln/HeaderSentinel/internal/scanner"
 	"github.com/ismailtsdln/HeaderSentinel/internal/scoring"
 	"github.com/ismailtsdln/HeaderSentinel/internal/utils"
@@ -27,8 +29,29 @@ var (
 	failThresholdFlag  int
 	silentFlag         bool
 	fixFlag            bool
+	disableFeatures    string
+	corsFlag           bool
+	vulnDBFlag         string
+	rulesFlag          string
+	pluginsFlag        string
+	pluginTimeoutFlag  int
+	profileFlag        string
+	headerFlags        headerList
 )
 
+// headerList collects repeated -H "Name: value" flags.
+type headerList []string
+
+func (h *headerList) String() string { return strings.Join(*h, ", ") }
+
+func (h *headerList) Set(value string) error {
+	if name, _, ok := strings.Cut(value, ":"); !ok || strings.TrimSpace(name) == "" {
+		return fmt.Errorf("expected \"Name: value\", got %q", value)
+	}
+	*h = append(*h, value)
+	return nil
+}
+
 func init() {
 	flag.StringVar(&urlFlag, "u", "", "Single URL to scan")
 	flag.StringVar(&inputFileFlag, "i", "", "Path to bulk input file")
@@ -40,6 +63,14 @@ func init() {
 	flag.IntVar(&failThresholdFlag, "fail-threshold", 0, "Exit with non-zero code if security score is below this threshold")
 	flag.BoolVar(&silentFlag, "silent", false, "Show only results, suppress progress messages")
 	flag.BoolVar(&fixFlag, "fix", false, "Show server-specific remediation snippets (Nginx, Apache)")
+	flag.Var(&headerFlags, "H", "Request header \"Name: value\" sent to the scanned hosts, e.g. an Authorization bearer token (repeatable)")
+	flag.BoolVar(&corsFlag, "cors", false, "Actively probe CORS with crafted Origin headers")
+	flag.StringVar(&profileFlag, "profile", "", "Rule profile: "+strings.Join(rules.ProfileNames(), ", "))
+	flag.StringVar(&rulesFlag, "rules", "", "Path to a YAML/JSON rule file or a directory of rule files")
+	flag.StringVar(&pluginsFlag, "plugins", "", "Comma-separated plugin executables that receive each response as JSON on stdin")
+	flag.IntVar(&pluginTimeoutFlag, "plugin-timeout", 10, "Timeout in seconds for each plugin run")
+	flag.StringVar(&vulnDBFlag, "vuln-db", "", "Path to a local JSON vulnerability feed for fingerprinted versions")
+	flag.StringVar(&disableFeatures, "disable-features", "", "Comma-separated Permissions-Policy features that must be disabled (e.g. camera,microphone)")
 }
 
 func main() {
@@ -72,7 +103,60 @@ func main() {
 	}
 
 	httpClient := utils.NewHTTPClient(time.Duration(timeoutFlag)*time.Second, followRedirectFlag)
+	if len(headerFlags) > 0 {
+		header := http.Header{}
+		for _, h := range headerFlags {
+			name, value, _ := strings.Cut(h, ":")
+			header.Add(strings.TrimSpace(name), strings.TrimSpace(value))
+		}
+		hosts := []string{}
+		for _, target := range targets {
+			if !strings.HasPrefix(target, "http") {
+				target = "https://" + target
+			}
+			if u, err := neturl.Parse(target); err == nil {
+				hosts = append(hosts, u.Hostname())
+			}
+		}
+		httpClient.SetHeaders(header, hosts)
+	}
 	headerScanner := scanner.NewHeaderScanner()
+	headerScanner.Client = httpClient.Client
+	headerScanner.ProbeCORS = corsFlag
+	if profileFlag != "" {
+		profile, ok := rules.FindProfile(profileFlag)
+		if !ok {
+			fmt.Printf("Unknown profile %q, expected one of: %s\n", profileFlag, strings.Join(rules.ProfileNames(), ", "))
+			os.Exit(1)
+		}
+		headerScanner.UseProfile(profile)
+	}
+	if rulesFlag != "" {
+		customRules, err := rules.LoadCustomRules(rulesFlag)
+		if err != nil {
+			fmt.Printf("Error loading rules: %v\n", err)
+			os.Exit(1)
+		}
+		headerScanner.CustomRules = customRules
+	}
+	if vulnDBFlag != "" {
+		vulns, err := rules.LoadVulnerabilities(vulnDBFlag)
+		if err != nil {
+			fmt.Printf("Error loading vulnerability feed: %v\n", err)
+			os.Exit(1)
+		}
+		headerScanner.Vulnerabilities = vulns
+	}
+	for _, path := range strings.Split(pluginsFlag, ",") {
+		if path = strings.TrimSpace(path); path != "" {
+			headerScanner.Register(&scanner.Plugin{Path: path, Timeout: time.Duration(pluginTimeoutFlag) * time.Second})
+		}
+	}
+	for _, feature := range strings.Split(disableFeatures, ",") {
+		if feature = strings.TrimSpace(feature); feature != "" {
+			headerScanner.DisabledFeatures = append(headerScanner.DisabledFeatures, feature)
+		}
+	}
 
 	reports := []report.ScanReport{}
 	reportChan := make(chan report.ScanReport, len(targets))
@@ -177,7 +261,12 @@ func scanURL(client *http.Client, headerScanner *scanner.HeaderScanner, url stri
 	defer resp.Body.Close()
 
 	rep.Status = scanner.AnalyzeStatus(resp)
-	findings := headerScanner.Scan(resp)
+	target := scanner.NewTarget(resp, rep.Redirects)
+	findings := headerScanner.ScanTarget(target)
+
+	rep.HSTSPreload = target.HSTSPreload
+	rep.Technologies = target.Technologies
+	rep.CrossOriginIsolation = target.Isolation
 	rep.SecurityScore = scoring.CalculateScore(findings)
 
 	return rep
@@ -0,0 +1,32 @@
+package headersentinel_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/ismailtsdln/HeaderSentinel"
+)
+
+func ExampleRegisterCheck() {
+	headersentinel.RegisterCheck(headersentinel.NewCheck("api-version", func(t *headersentinel.Target) []headersentinel.Finding {
+		if t.Response.Header.Get("X-API-Version") != "" {
+			return nil
+		}
+		return []headersentinel.Finding{{Header: "X-API-Version", Status: "missing", Risk: headersentinel.RiskLow}}
+	}))
+
+	rec := httptest.NewRecorder()
+	rec.Header().Set("Content-Type", "application/json")
+	rec.WriteHeader(http.StatusOK)
+	resp := rec.Result()
+	resp.Request = httptest.NewRequest(http.MethodGet, "https://example.com/", nil)
+
+	s := headersentinel.NewHeaderScanner()
+	for _, f := range s.ScanTarget(headersentinel.NewTarget(resp, headersentinel.RedirectResult{})) {
+		if f.Header == "X-API-Version" {
+			fmt.Println(f.Status, f.Risk)
+		}
+	}
+	// Output: missing LOW
+}
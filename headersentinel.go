@@ -0,0 +1,86 @@
+// Package headersentinel is the public API for embedding the HeaderSentinel
+// scanner and extending it with custom checks. The types are aliases of the
+// internal scanner and rules packages, so values pass freely between them.
+package headersentinel
+
+import (
+	"net/http"
+
+	"github.com/ismailtsdln/HeaderSentinel/internal/rules"
+	"github.com/ismailtsdln/HeaderSentinel/internal/scanner"
+)
+
+type (
+	// HeaderScanner runs checks against responses.
+	HeaderScanner = scanner.HeaderScanner
+	// Check is a single analysis run against every scanned response.
+	Check = scanner.Check
+	// Target is everything a check can inspect about one scanned URL.
+	Target = scanner.Target
+	// Finding is a single security finding.
+	Finding = scanner.Finding
+	// RedirectResult is a traced redirect chain.
+	RedirectResult = scanner.RedirectResult
+	// RedirectHop is one response in a redirect chain.
+	RedirectHop = scanner.RedirectHop
+	// MetaPolicies holds the policies delivered through <meta> tags.
+	MetaPolicies = scanner.MetaPolicies
+	// Technology is a product identified from headers and cookies.
+	Technology = scanner.Technology
+	// IsolationResult is the cross-origin isolation verdict for a response.
+	IsolationResult = scanner.IsolationResult
+	// HSTSPreloadResult describes whether a host meets the preload requirements.
+	HSTSPreloadResult = scanner.HSTSPreloadResult
+	// RiskLevel is the severity of a finding.
+	RiskLevel = rules.RiskLevel
+	// SecurityRule describes a check and its remediation metadata.
+	SecurityRule = rules.SecurityRule
+	// Profile tailors the rule set to a kind of application.
+	Profile = rules.Profile
+)
+
+// Risk levels, from most to least severe.
+const (
+	RiskCritical = rules.RiskCritical
+	RiskHigh     = rules.RiskHigh
+	RiskMedium   = rules.RiskMedium
+	RiskLow      = rules.RiskLow
+	RiskInfo     = rules.RiskInfo
+)
+
+// NewHeaderScanner creates a scanner with the built-in and registered checks.
+func NewHeaderScanner() *HeaderScanner {
+	return scanner.NewHeaderScanner()
+}
+
+// NewCheck wraps a function as a Check.
+func NewCheck(name string, run func(target *Target) []Finding) Check {
+	return scanner.NewCheck(name, run)
+}
+
+// RegisterCheck adds a check to every scanner created afterwards, replacing
+// any built-in or registered check of the same name.
+func RegisterCheck(check Check) {
+	scanner.RegisterCheck(check)
+}
+
+// RegisteredChecks returns the checks added with RegisterCheck.
+func RegisteredChecks() []Check {
+	return scanner.RegisteredChecks()
+}
+
+// NewTarget builds the check input for a response. The response body stays
+// readable for later consumers.
+func NewTarget(resp *http.Response, redirects RedirectResult) *Target {
+	return scanner.NewTarget(resp, redirects)
+}
+
+// AnalyzeRedirects follows the redirect chain starting at url.
+func AnalyzeRedirects(client *http.Client, url string) (RedirectResult, error) {
+	return scanner.AnalyzeRedirects(client, url)
+}
+
+// FindProfile returns the built-in profile with the given name.
+func FindProfile(name string) (Profile, bool) {
+	return rules.FindProfile(name)
+}
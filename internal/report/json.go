@@ -9,10 +9,13 @@ import (
 
 // ScanReport represents the full scan result for a URL.
 type ScanReport struct {
-	URL           string                 `json:"url"`
-	Status        scanner.StatusResult   `json:"status"`
-	Redirects     scanner.RedirectResult `json:"redirects"`
-	SecurityScore scoring.ScoreResult    `json:"security_score"`
+	URL                  string                     `json:"url"`
+	Status               scanner.StatusResult       `json:"status"`
+	Redirects            scanner.RedirectResult     `json:"redirects"`
+	SecurityScore        scoring.ScoreResult        `json:"security_score"`
+	HSTSPreload          *scanner.HSTSPreloadResult `json:"hsts_preload,omitempty"`
+	CrossOriginIsolation *scanner.IsolationResult   `json:"cross_origin_isolation,omitempty"`
+	Technologies         []scanner.Technology       `json:"technologies,omitempty"`
 }
 
 // JSONFormatter formats the report as JSON.
@@ -3,6 +3,9 @@ package report
 import (
 	"encoding/json"
 	"fmt"
+
+	"github.com/ismailtsdln/HeaderSentinel/internal/rules"
+	"github.com/ismailtsdln/HeaderSentinel/internal/scanner"
 )
 
 // SARIFReport represents a basic SARIF structure.
@@ -25,6 +28,21 @@ type Driver struct {
 	Name           string `json:"name"`
 	InformationURI string `json:"informationUri"`
 	Version        string `json:"version"`
+	Rules          []Rule `json:"rules,omitempty"`
+}
+
+// Rule describes a reporting rule referenced by results through its ID.
+type Rule struct {
+	ID               string         `json:"id"`
+	Name             string         `json:"name,omitempty"`
+	ShortDescription Message        `json:"shortDescription"`
+	Help             Message        `json:"help"`
+	HelpURI          string         `json:"helpUri,omitempty"`
+	Properties       RuleProperties `json:"properties"`
+}
+
+type RuleProperties struct {
+	Tags []string `json:"tags,omitempty"`
 }
 
 type Result struct {
@@ -68,10 +86,12 @@ func SARIFFormatter(reports []ScanReport) (string, error) {
 			},
 		},
 	}
+	run := &sarif.Runs[0]
+	seen := map[string]bool{}
 
 	for _, rep := range reports {
 		for _, f := range rep.SecurityScore.Findings {
-			if f.Status == "present" && f.Risk == "INFO" {
+			if (f.Status == "present" || f.Status == "strict") && f.Risk == "INFO" {
 				continue
 			}
 
@@ -82,8 +102,17 @@ func SARIFFormatter(reports []ScanReport) (string, error) {
 				level = "note"
 			}
 
+			ruleID := f.RuleID
+			if ruleID == "" {
+				ruleID = f.Header
+			}
+			if !seen[ruleID] {
+				seen[ruleID] = true
+				run.Tool.Driver.Rules = append(run.Tool.Driver.Rules, sarifRule(ruleID, f))
+			}
+
 			res := Result{
-				RuleID: f.Header,
+				RuleID: ruleID,
 				Level:  level,
 				Message: Message{
 					Text: fmt.Sprintf("%s: %s. Recommendation: %s", f.Header, f.Description, f.Recommendation),
@@ -98,7 +127,7 @@ func SARIFFormatter(reports []ScanReport) (string, error) {
 					},
 				},
 			}
-			sarif.Runs[0].Results = append(sarif.Runs[0].Results, res)
+			run.Results = append(run.Results, res)
 		}
 	}
 
@@ -108,3 +137,40 @@ func SARIFFormatter(reports []ScanReport) (string, error) {
 	}
 	return string(b), nil
 }
+
+// sarifRule describes the rule of a finding. Built-in rules use their
+// catalogue entry, custom and plugin rules the metadata of the finding.
+func sarifRule(id string, f scanner.Finding) Rule {
+	rule, ok := rules.FindRuleByID(id)
+	if !ok {
+		rule = rules.SecurityRule{
+			ID:             id,
+			Header:         f.Header,
+			Description:    f.Description,
+			Recommendation: f.Recommendation,
+			CWE:            f.CWE,
+			OWASP:          f.OWASP,
+			ASVS:           f.ASVS,
+			DocURL:         f.DocURL,
+		}
+	}
+
+	tags := []string{"security"}
+	for _, ref := range []string{rule.CWE, rule.OWASP} {
+		if ref != "" {
+			tags = append(tags, ref)
+		}
+	}
+	if rule.ASVS != "" {
+		tags = append(tags, "ASVS "+rule.ASVS)
+	}
+
+	return Rule{
+		ID:               id,
+		Name:             rule.CheckName,
+		ShortDescription: Message{Text: rule.Description},
+		Help:             Message{Text: rule.Recommendation},
+		HelpURI:          rule.DocURL,
+		Properties:       RuleProperties{Tags: tags},
+	}
+}
@@ -38,9 +38,51 @@ func PrintTable(report ScanReport, showFix bool) {
 		}
 	}
 
+	if report.HSTSPreload != nil {
+		if report.HSTSPreload.Eligible {
+			fmt.Printf("HSTS Preload: %seligible%s\n", colorGreen, colorReset)
+		} else {
+			fmt.Printf("HSTS Preload: %snot eligible%s\n", colorYellow, colorReset)
+			for _, problem := range report.HSTSPreload.Problems {
+				fmt.Printf("  - %s\n", problem)
+			}
+		}
+	}
+
+	if len(report.Technologies) > 0 {
+		fmt.Println("Technologies:")
+		for _, tech := range report.Technologies {
+			line := tech.Name
+			if tech.Version != "" {
+				line += " " + tech.Version
+			}
+			if tech.EndOfLife {
+				line += fmt.Sprintf(" %s(end-of-life)%s", colorRed, colorReset)
+			}
+			if tech.SupportUnknown {
+				line += " (support status unknown)"
+			}
+			if len(tech.CVEs) > 0 {
+				line += fmt.Sprintf(" %s(%d known CVEs)%s", colorRed, len(tech.CVEs), colorReset)
+			}
+			fmt.Printf("  - %s [%s]\n", line, tech.Category)
+		}
+	}
+
+	if report.CrossOriginIsolation != nil {
+		if report.CrossOriginIsolation.Isolated {
+			fmt.Printf("Cross-Origin Isolation: %sisolated%s\n", colorGreen, colorReset)
+		} else {
+			fmt.Printf("Cross-Origin Isolation: %snot isolated%s\n", colorYellow, colorReset)
+		}
+		for _, problem := range report.CrossOriginIsolation.Problems {
+			fmt.Printf("  - %s\n", problem)
+		}
+	}
+
 	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
-	fmt.Fprintf(w, "\n%sHEADER\tSTATUS\tRISK\tRECOMMENDATION%s\n", colorCyan, colorReset)
-	fmt.Fprintln(w, "------\t------\t----\t--------------")
+	fmt.Fprintf(w, "\n%sID\tHEADER\tSTATUS\tRISK\tRECOMMENDATION%s\n", colorCyan, colorReset)
+	fmt.Fprintln(w, "--\t------\t------\t----\t--------------")
 
 	for _, f := range report.SecurityScore.Findings {
 		riskColor := colorReset
@@ -53,7 +95,12 @@ func PrintTable(report ScanReport, showFix bool) {
 			riskColor = colorBlue
 		}
 
-		fmt.Fprintf(w, "%s\t%s\t%s%s%s\t%s\n", f.Header, f.Status, riskColor, f.Risk, colorReset, f.Recommendation)
+		header := f.Header
+		if f.Cookie != "" {
+			header += " (" + f.Cookie + ")"
+		}
+
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s%s%s\t%s\n", f.RuleID, header, f.Status, riskColor, f.Risk, colorReset, f.Recommendation)
 
 		if showFix && (f.NginxConfig != "" || f.ApacheConfig != "") {
 			w.Flush() // Flush to ensure previous line is printed
@@ -63,6 +110,9 @@ func PrintTable(report ScanReport, showFix bool) {
 			if f.ApacheConfig != "" {
 				fmt.Printf("  %s[Apache]%s %s\n", colorBlue, colorReset, f.ApacheConfig)
 			}
+			if f.DocURL != "" {
+				fmt.Printf("  %s[Docs]%s %s\n", colorBlue, colorReset, f.DocURL)
+			}
 		}
 	}
 	w.Flush()
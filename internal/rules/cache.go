@@ -0,0 +1,60 @@
+package rules
+
+// CacheRules contains the checks applied to the caching headers of a response.
+var CacheRules = []SecurityRule{
+	{
+		ID:             "HS-CACHE-001",
+		Header:         "Cache-Control",
+		CheckName:      "Cacheable Response Sets Cookies",
+		Risk:           RiskMedium,
+		Description:    "The response sets cookies but may be stored by shared caches, which can then serve one user's cookies to others.",
+		Recommendation: "Send Cache-Control: no-store (or private) on responses that set cookies.",
+		Exploit:        "Session fixation or account takeover through a CDN serving cached Set-Cookie headers.",
+		NginxConfig:    "add_header Cache-Control \"no-store\" always;",
+		ApacheConfig:   "Header always set Cache-Control \"no-store\"",
+		CWE:            "CWE-524",
+		OWASP:          "A05:2021 Security Misconfiguration",
+		ASVS:           "V8.1.1",
+		DocURL:         "https://developer.mozilla.org/en-US/docs/Web/HTTP/Caching",
+	},
+	{
+		ID:             "HS-CACHE-002",
+		Header:         "Cache-Control",
+		CheckName:      "Authenticated Response Without no-store",
+		Risk:           RiskMedium,
+		Description:    "An authenticated response does not set no-store, so browsers, proxies or CDNs may keep a copy of private data.",
+		Recommendation: "Send Cache-Control: no-store on authenticated pages.",
+		Exploit:        "Private data disclosure from shared or local caches, web cache deception.",
+		NginxConfig:    "add_header Cache-Control \"no-store\" always;",
+		ApacheConfig:   "Header always set Cache-Control \"no-store\"",
+		CWE:            "CWE-525",
+		OWASP:          "A05:2021 Security Misconfiguration",
+		ASVS:           "V8.2.1",
+		DocURL:         "https://developer.mozilla.org/en-US/docs/Web/HTTP/Headers/Cache-Control",
+	},
+	{
+		ID:             "HS-CACHE-003",
+		Header:         "Vary",
+		CheckName:      "Cacheable Response Missing Vary",
+		Risk:           RiskLow,
+		Description:    "A cacheable authenticated response does not vary on Cookie or Authorization, so a cache may serve it to other users.",
+		Recommendation: "Add Vary: Cookie, Authorization, or better, mark the response no-store.",
+		Exploit:        "Web cache poisoning and private data disclosure.",
+		CWE:            "CWE-524",
+		OWASP:          "A05:2021 Security Misconfiguration",
+		ASVS:           "V8.1.1",
+		DocURL:         "https://developer.mozilla.org/en-US/docs/Web/HTTP/Headers/Vary",
+	},
+	{
+		ID:             "HS-CACHE-004",
+		Header:         "Pragma",
+		CheckName:      "Legacy Pragma Cache Control",
+		Risk:           RiskInfo,
+		Description:    "Only the HTTP/1.0 Pragma: no-cache header is set. Modern caches ignore it in responses.",
+		Recommendation: "Use Cache-Control: no-store or no-cache instead of Pragma.",
+		CWE:            "CWE-525",
+		OWASP:          "A05:2021 Security Misconfiguration",
+		ASVS:           "V8.2.1",
+		DocURL:         "https://developer.mozilla.org/en-US/docs/Web/HTTP/Headers/Pragma",
+	},
+}
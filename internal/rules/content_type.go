@@ -0,0 +1,58 @@
+package rules
+
+// ContentTypeRules contains the checks applied to the Content-Type header.
+var ContentTypeRules = []SecurityRule{
+	{
+		ID:             "HS-CT-001",
+		Header:         "Content-Type",
+		CheckName:      "Missing Content-Type",
+		Risk:           RiskLow,
+		Description:    "The response has a body but no Content-Type, so browsers guess the type from the content.",
+		Recommendation: "Send an explicit Content-Type on every response with a body.",
+		Exploit:        "MIME-confusion XSS when user content is sniffed as HTML.",
+		CWE:            "CWE-436",
+		OWASP:          "A05:2021 Security Misconfiguration",
+		ASVS:           "V14.4.1",
+		DocURL:         "https://developer.mozilla.org/en-US/docs/Web/HTTP/Headers/Content-Type",
+	},
+	{
+		ID:             "HS-CT-002",
+		Header:         "Content-Type",
+		CheckName:      "HTML Without Charset",
+		Risk:           RiskLow,
+		Description:    "HTML is served without a charset in Content-Type or a <meta> tag, so browsers may guess an encoding such as UTF-7 that turns harmless input into markup.",
+		Recommendation: "Use Content-Type: text/html; charset=utf-8.",
+		Exploit:        "Charset-sniffing XSS.",
+		NginxConfig:    "charset utf-8;",
+		ApacheConfig:   "AddDefaultCharset UTF-8",
+		CWE:            "CWE-838",
+		OWASP:          "A05:2021 Security Misconfiguration",
+		ASVS:           "V14.4.1",
+		DocURL:         "https://developer.mozilla.org/en-US/docs/Web/HTTP/Headers/Content-Type",
+	},
+	{
+		ID:             "HS-CT-003",
+		Header:         "Content-Type",
+		CheckName:      "JSON Served as HTML",
+		Risk:           RiskMedium,
+		Description:    "A JSON body is served as text/html, so any reflected value in it is rendered as markup when the URL is opened directly.",
+		Recommendation: "Serve JSON as application/json.",
+		Exploit:        "Reflected XSS through JSON API responses.",
+		CWE:            "CWE-79",
+		OWASP:          "A03:2021 Injection",
+		ASVS:           "V14.4.1",
+		DocURL:         "https://developer.mozilla.org/en-US/docs/Web/HTTP/Headers/Content-Type",
+	},
+	{
+		ID:             "HS-CT-004",
+		Header:         "X-Content-Type-Options",
+		CheckName:      "nosniff With Mismatched Content-Type",
+		Risk:           RiskLow,
+		Description:    "X-Content-Type-Options: nosniff is set, but the declared Content-Type does not match the body, so browsers may block or misrender the resource.",
+		Recommendation: "Fix the Content-Type so it matches the content actually served.",
+		CWE:            "CWE-436",
+		OWASP:          "A05:2021 Security Misconfiguration",
+		ASVS:           "V14.4.4",
+		DocURL:         "https://developer.mozilla.org/en-US/docs/Web/HTTP/Headers/X-Content-Type-Options",
+	},
+}
@@ -0,0 +1,107 @@
+package rules
+
+// CookieRules contains the attribute-level checks applied to each Set-Cookie header.
+var CookieRules = []SecurityRule{
+	{
+		ID:             "HS-COOK-002",
+		Header:         "Set-Cookie",
+		CheckName:      "Insecure Cookie (Missing HttpOnly)",
+		Risk:           RiskMedium,
+		Description:    "The HttpOnly flag help to prevent XSS attacks from stealing cookies.",
+		Recommendation: "Add the 'HttpOnly' flag to all sensitive cookies.",
+		Exploit:        "Cookie theft via XSS.",
+		CWE:            "CWE-1004",
+		OWASP:          "A05:2021 Security Misconfiguration",
+		ASVS:           "V3.4.2",
+		DocURL:         "https://developer.mozilla.org/en-US/docs/Web/HTTP/Headers/Set-Cookie#httponly",
+	},
+	{
+		ID:             "HS-COOK-003",
+		Header:         "Set-Cookie",
+		CheckName:      "Insecure Cookie (Missing Secure)",
+		Risk:           RiskMedium,
+		Description:    "The Secure flag ensures that the cookie is only sent over HTTPS.",
+		Recommendation: "Add the 'Secure' flag to all sensitive cookies.",
+		Exploit:        "Cookie interception over insecure connections (MITM).",
+		CWE:            "CWE-614",
+		OWASP:          "A02:2021 Cryptographic Failures",
+		ASVS:           "V3.4.1",
+		DocURL:         "https://developer.mozilla.org/en-US/docs/Web/HTTP/Headers/Set-Cookie#secure",
+	},
+	{
+		ID:             "HS-COOK-004",
+		Header:         "Set-Cookie",
+		CheckName:      "Insecure Cookie (Missing SameSite)",
+		Risk:           RiskLow,
+		Description:    "The SameSite flag helps to protect against CSRF attacks.",
+		Recommendation: "Add 'SameSite=Lax' or 'SameSite=Strict' to your cookies.",
+		Exploit:        "Cross-Site Request Forgery (CSRF).",
+		CWE:            "CWE-1275",
+		OWASP:          "A01:2021 Broken Access Control",
+		ASVS:           "V3.4.3",
+		DocURL:         "https://developer.mozilla.org/en-US/docs/Web/HTTP/Headers/Set-Cookie#samesitesamesite-value",
+	},
+	{
+		ID:             "HS-COOK-005",
+		Header:         "Set-Cookie",
+		CheckName:      "Cookie SameSite=None Without Secure",
+		Risk:           RiskMedium,
+		Description:    "SameSite=None requires the Secure flag. Modern browsers reject the cookie, older ones send it on every cross-site request.",
+		Recommendation: "Add the 'Secure' flag, or use SameSite=Lax if the cookie is not needed cross-site.",
+		Exploit:        "Cross-Site Request Forgery (CSRF), cookie interception.",
+		CWE:            "CWE-614",
+		OWASP:          "A02:2021 Cryptographic Failures",
+		ASVS:           "V3.4.3",
+		DocURL:         "https://developer.mozilla.org/en-US/docs/Web/HTTP/Headers/Set-Cookie#samesitesamesite-value",
+	},
+	{
+		ID:             "HS-COOK-006",
+		Header:         "Set-Cookie",
+		CheckName:      "Cookie Prefix Violation",
+		Risk:           RiskMedium,
+		Description:    "A __Secure- or __Host- cookie does not meet its prefix requirements, so browsers reject it.",
+		Recommendation: "__Secure- cookies need Secure; __Host- cookies need Secure, Path=/ and no Domain attribute.",
+		CWE:            "CWE-565",
+		OWASP:          "A05:2021 Security Misconfiguration",
+		ASVS:           "V3.4.4",
+		DocURL:         "https://developer.mozilla.org/en-US/docs/Web/HTTP/Headers/Set-Cookie#cookie_prefixes",
+	},
+	{
+		ID:             "HS-COOK-007",
+		Header:         "Set-Cookie",
+		CheckName:      "Cookie Overly Broad Domain",
+		Risk:           RiskLow,
+		Description:    "The Domain attribute shares the cookie with every subdomain of the domain it names, even when that is the host that set it.",
+		Recommendation: "Omit the Domain attribute (or use the __Host- prefix) so the cookie stays on the host that set it.",
+		Exploit:        "Cookie theft or fixation from a compromised or untrusted subdomain.",
+		CWE:            "CWE-668",
+		OWASP:          "A05:2021 Security Misconfiguration",
+		ASVS:           "V3.4.4",
+		DocURL:         "https://developer.mozilla.org/en-US/docs/Web/HTTP/Headers/Set-Cookie#domaindomain-value",
+	},
+	{
+		ID:             "HS-COOK-008",
+		Header:         "Set-Cookie",
+		CheckName:      "Cookie Missing Path",
+		Risk:           RiskInfo,
+		Description:    "No Path attribute is set, so the cookie is scoped to the directory of the request that set it and can be shadowed by cookies with the same name.",
+		Recommendation: "Set an explicit Path, usually Path=/.",
+		CWE:            "CWE-668",
+		OWASP:          "A05:2021 Security Misconfiguration",
+		ASVS:           "V3.4.5",
+		DocURL:         "https://developer.mozilla.org/en-US/docs/Web/HTTP/Headers/Set-Cookie#pathpath-value",
+	},
+	{
+		ID:             "HS-COOK-009",
+		Header:         "Set-Cookie",
+		CheckName:      "Long-Lived Session Cookie",
+		Risk:           RiskLow,
+		Description:    "A session cookie is persisted for a long time, extending the window in which a stolen cookie stays valid.",
+		Recommendation: "Keep session cookies non-persistent or limit Max-Age/Expires to the session lifetime.",
+		Exploit:        "Session hijacking with a stolen cookie.",
+		CWE:            "CWE-613",
+		OWASP:          "A07:2021 Identification and Authentication Failures",
+		ASVS:           "V3.3.2",
+		DocURL:         "https://developer.mozilla.org/en-US/docs/Web/HTTP/Headers/Set-Cookie#max-agenumber",
+	},
+}
@@ -0,0 +1,82 @@
+package rules
+
+// CORSRules contains the checks applied by the active CORS probe. Risks assume
+// Access-Control-Allow-Credentials: true and are lowered when it is absent.
+var CORSRules = []SecurityRule{
+	{
+		ID:             "HS-CORS-001",
+		Header:         "Access-Control-Allow-Origin",
+		CheckName:      "CORS Reflected Origin",
+		Risk:           RiskCritical,
+		Description:    "The server reflects an arbitrary Origin in Access-Control-Allow-Origin, so any site can read its responses.",
+		Recommendation: "Compare the Origin against an exact allowlist before echoing it.",
+		Exploit:        "Cross-origin theft of authenticated data from any attacker-controlled page.",
+		CWE:            "CWE-942",
+		OWASP:          "A01:2021 Broken Access Control",
+		ASVS:           "V14.5.3",
+		DocURL:         "https://developer.mozilla.org/en-US/docs/Web/HTTP/CORS",
+	},
+	{
+		ID:             "HS-CORS-002",
+		Header:         "Access-Control-Allow-Origin",
+		CheckName:      "CORS Lookalike Origin Trusted",
+		Risk:           RiskHigh,
+		Description:    "The origin check matches a prefix or suffix of the host, so attacker domains that embed the host name are trusted.",
+		Recommendation: "Match origins exactly (scheme, host and port) instead of using substring or regex checks.",
+		Exploit:        "Registering a lookalike domain to read cross-origin responses.",
+		CWE:            "CWE-942",
+		OWASP:          "A01:2021 Broken Access Control",
+		ASVS:           "V14.5.3",
+		DocURL:         "https://developer.mozilla.org/en-US/docs/Web/HTTP/CORS",
+	},
+	{
+		ID:             "HS-CORS-003",
+		Header:         "Access-Control-Allow-Origin",
+		CheckName:      "CORS Null Origin Trusted",
+		Risk:           RiskHigh,
+		Description:    "The 'null' origin is trusted. Sandboxed iframes and data: URLs send Origin: null, so any site can obtain it.",
+		Recommendation: "Never allow the 'null' origin.",
+		Exploit:        "Reading cross-origin responses from a sandboxed iframe.",
+		CWE:            "CWE-942",
+		OWASP:          "A01:2021 Broken Access Control",
+		ASVS:           "V14.5.3",
+		DocURL:         "https://developer.mozilla.org/en-US/docs/Web/HTTP/CORS",
+	},
+	{
+		ID:             "HS-CORS-004",
+		Header:         "Access-Control-Allow-Origin",
+		CheckName:      "CORS Insecure Origin Trusted",
+		Risk:           RiskMedium,
+		Description:    "The http:// variant of the host is trusted, so a network attacker can inject script into it and read responses.",
+		Recommendation: "Only allow https:// origins.",
+		Exploit:        "Man-in-the-Middle injection into the plain HTTP origin.",
+		CWE:            "CWE-942",
+		OWASP:          "A01:2021 Broken Access Control",
+		ASVS:           "V14.5.3",
+		DocURL:         "https://developer.mozilla.org/en-US/docs/Web/HTTP/CORS",
+	},
+	{
+		ID:             "HS-CORS-005",
+		Header:         "Access-Control-Allow-Origin",
+		CheckName:      "CORS Wildcard With Credentials",
+		Risk:           RiskMedium,
+		Description:    "Access-Control-Allow-Origin: * is combined with Access-Control-Allow-Credentials: true. Browsers reject this, which often leads developers to reflect the Origin instead.",
+		Recommendation: "Use an explicit origin allowlist when credentials are needed, or drop Access-Control-Allow-Credentials.",
+		CWE:            "CWE-942",
+		OWASP:          "A01:2021 Broken Access Control",
+		ASVS:           "V14.5.3",
+		DocURL:         "https://developer.mozilla.org/en-US/docs/Web/HTTP/CORS",
+	},
+	{
+		ID:             "HS-CORS-006",
+		Header:         "Access-Control-Allow-Methods",
+		CheckName:      "CORS Permissive Preflight",
+		Risk:           RiskLow,
+		Description:    "The preflight response allows any method or header for an untrusted origin.",
+		Recommendation: "List only the methods and headers the API needs in Access-Control-Allow-Methods and Access-Control-Allow-Headers.",
+		CWE:            "CWE-942",
+		OWASP:          "A05:2021 Security Misconfiguration",
+		ASVS:           "V14.5.3",
+		DocURL:         "https://developer.mozilla.org/en-US/docs/Web/HTTP/Headers/Access-Control-Allow-Methods",
+	},
+}
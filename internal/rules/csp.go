@@ -0,0 +1,162 @@
+package rules
+
+// CSPRules contains the directive-level checks applied to a Content-Security-Policy.
+var CSPRules = []SecurityRule{
+	{
+		ID:             "HS-CSP-002",
+		Header:         "Content-Security-Policy",
+		CheckName:      "CSP Wildcard Source",
+		Risk:           RiskHigh,
+		Description:    "The policy allows resources to be loaded from any host, which defeats the purpose of the directive.",
+		Recommendation: "Replace '*' and scheme-only sources with an explicit list of trusted origins, or use nonces/hashes for scripts.",
+		Exploit:        "Loading attacker-controlled scripts or plugins from arbitrary hosts.",
+		CWE:            "CWE-79",
+		OWASP:          "A03:2021 Injection",
+		ASVS:           "V14.4.3",
+		DocURL:         "https://developer.mozilla.org/en-US/docs/Web/HTTP/Headers/Content-Security-Policy",
+	},
+	{
+		ID:             "HS-CSP-003",
+		Header:         "Content-Security-Policy",
+		CheckName:      "CSP Unsafe Scheme in script-src",
+		Risk:           RiskHigh,
+		Description:    "The script-src directive allows 'data:' or 'blob:' URLs, which lets an attacker inject script without hosting it anywhere.",
+		Recommendation: "Remove 'data:' and 'blob:' from script-src (and default-src when it is used as a fallback).",
+		Exploit:        "XSS via data: or blob: script URLs.",
+		CWE:            "CWE-79",
+		OWASP:          "A03:2021 Injection",
+		ASVS:           "V14.4.3",
+		DocURL:         "https://developer.mozilla.org/en-US/docs/Web/HTTP/Headers/Content-Security-Policy/script-src",
+	},
+	{
+		ID:             "HS-CSP-004",
+		Header:         "Content-Security-Policy",
+		CheckName:      "CSP Missing object-src",
+		Risk:           RiskMedium,
+		Description:    "Neither object-src nor default-src is defined, so plugins such as Flash or PDF viewers can be loaded from anywhere.",
+		Recommendation: "Set object-src 'none'.",
+		Exploit:        "Script execution through plugin content.",
+		NginxConfig:    "add_header Content-Security-Policy \"object-src 'none';\";",
+		ApacheConfig:   "Header set Content-Security-Policy \"object-src 'none';\"",
+		CWE:            "CWE-79",
+		OWASP:          "A03:2021 Injection",
+		ASVS:           "V14.4.3",
+		DocURL:         "https://developer.mozilla.org/en-US/docs/Web/HTTP/Headers/Content-Security-Policy/object-src",
+	},
+	{
+		ID:             "HS-CSP-005",
+		Header:         "Content-Security-Policy",
+		CheckName:      "CSP Missing base-uri",
+		Risk:           RiskLow,
+		Description:    "The base-uri directive is not set and does not fall back to default-src, so injected <base> tags can redirect relative script URLs.",
+		Recommendation: "Set base-uri 'self' or base-uri 'none'.",
+		Exploit:        "Base tag injection to hijack relative script loads.",
+		NginxConfig:    "add_header Content-Security-Policy \"base-uri 'self';\";",
+		ApacheConfig:   "Header set Content-Security-Policy \"base-uri 'self';\"",
+		CWE:            "CWE-79",
+		OWASP:          "A03:2021 Injection",
+		ASVS:           "V14.4.3",
+		DocURL:         "https://developer.mozilla.org/en-US/docs/Web/HTTP/Headers/Content-Security-Policy/base-uri",
+	},
+	{
+		ID:             "HS-CSP-006",
+		Header:         "Content-Security-Policy",
+		CheckName:      "CSP Insecure Scheme Source",
+		Risk:           RiskMedium,
+		Description:    "The policy allows resources to be loaded over plain HTTP.",
+		Recommendation: "Only allow https: origins in the policy.",
+		Exploit:        "Injection of resources by a network attacker (MITM).",
+		CWE:            "CWE-319",
+		OWASP:          "A02:2021 Cryptographic Failures",
+		ASVS:           "V14.4.3",
+		DocURL:         "https://developer.mozilla.org/en-US/docs/Web/HTTP/Headers/Content-Security-Policy",
+	},
+	{
+		ID:             "HS-CSP-007",
+		Header:         "Content-Security-Policy",
+		CheckName:      "CSP Unsafe Inline",
+		Risk:           RiskMedium,
+		Description:    "The script-src directive allows 'unsafe-inline', which permits inline event handlers and <script> blocks.",
+		Recommendation: "Remove 'unsafe-inline' and move inline scripts to external files or protect them with nonces/hashes.",
+		Exploit:        "Reflected and stored XSS.",
+		CWE:            "CWE-79",
+		OWASP:          "A03:2021 Injection",
+		ASVS:           "V14.4.3",
+		DocURL:         "https://developer.mozilla.org/en-US/docs/Web/HTTP/Headers/Content-Security-Policy/script-src#unsafe_inline_script",
+	},
+	{
+		ID:             "HS-CSP-008",
+		Header:         "Content-Security-Policy",
+		CheckName:      "CSP Unsafe Eval",
+		Risk:           RiskMedium,
+		Description:    "The script-src directive allows 'unsafe-eval', which permits eval() and similar string-to-code APIs.",
+		Recommendation: "Remove 'unsafe-eval' and refactor code that relies on eval().",
+		Exploit:        "DOM-based XSS through eval sinks.",
+		CWE:            "CWE-95",
+		OWASP:          "A03:2021 Injection",
+		ASVS:           "V14.4.3",
+		DocURL:         "https://developer.mozilla.org/en-US/docs/Web/HTTP/Headers/Content-Security-Policy/script-src#unsafe_eval_expressions",
+	},
+	{
+		ID:             "HS-CSP-009",
+		Header:         "Content-Security-Policy",
+		CheckName:      "CSP Bypassable Host",
+		Risk:           RiskHigh,
+		Description:    "The script allowlist contains a host that serves JSONP endpoints, AngularJS or user-controlled content, which can be used to bypass the policy.",
+		Recommendation: "Remove the host from script-src or switch to a nonce-based policy with 'strict-dynamic'.",
+		Exploit:        "XSS through JSONP callbacks or script gadgets hosted on an allowlisted domain.",
+		CWE:            "CWE-79",
+		OWASP:          "A03:2021 Injection",
+		ASVS:           "V14.4.3",
+		DocURL:         "https://csp-evaluator.withgoogle.com/",
+	},
+	{
+		ID:             "HS-CSP-010",
+		Header:         "Content-Security-Policy",
+		CheckName:      "CSP Invalid Reporting Endpoint",
+		Risk:           RiskLow,
+		Description:    "A report-uri or report-to target is not an absolute HTTPS URL or does not match a Reporting-Endpoints/Report-To group, so violation reports are lost.",
+		Recommendation: "Point report-uri at an absolute https:// URL and declare report-to groups in a Reporting-Endpoints header.",
+		NginxConfig:    "add_header Reporting-Endpoints \"csp-endpoint=\\\"https://example.com/csp-reports\\\"\" always;",
+		ApacheConfig:   "Header always set Reporting-Endpoints \"csp-endpoint=\\\"https://example.com/csp-reports\\\"\"",
+		CWE:            "CWE-778",
+		OWASP:          "A09:2021 Security Logging and Monitoring Failures",
+		DocURL:         "https://developer.mozilla.org/en-US/docs/Web/HTTP/Headers/Content-Security-Policy/report-to",
+	},
+	{
+		ID:             "HS-CSP-011",
+		Header:         "Content-Security-Policy-Report-Only",
+		CheckName:      "CSP Report-Only Without Reporting",
+		Risk:           RiskLow,
+		Description:    "A report-only policy has no report-uri or report-to directive, so it neither blocks nor reports anything.",
+		Recommendation: "Add a report-to (or report-uri) directive so violations can be reviewed before enforcing the policy.",
+		CWE:            "CWE-778",
+		OWASP:          "A09:2021 Security Logging and Monitoring Failures",
+		DocURL:         "https://developer.mozilla.org/en-US/docs/Web/HTTP/Headers/Content-Security-Policy-Report-Only",
+	},
+	{
+		ID:             "HS-CSP-012",
+		Header:         "Content-Security-Policy",
+		CheckName:      "CSP Strict Policy",
+		Risk:           RiskInfo,
+		Description:    "The policy is a strict, nonce- or hash-based CSP that does not rely on host allowlists.",
+		Recommendation: "Keep nonces unpredictable and regenerated on every response.",
+		ASVS:           "V14.4.3",
+		DocURL:         "https://web.dev/articles/strict-csp",
+	},
+	{
+		ID:             "HS-CSP-013",
+		Header:         "Content-Security-Policy-Report-Only",
+		CheckName:      "CSP Not Enforced",
+		Risk:           RiskMedium,
+		Description:    "Only Content-Security-Policy-Report-Only is set, so violations are reported but nothing is blocked.",
+		Recommendation: "Once the reports are clean, send the same policy as Content-Security-Policy to enforce it.",
+		Exploit:        "XSS, Clickjacking, Data injection.",
+		NginxConfig:    "add_header Content-Security-Policy \"default-src 'self';\" always;",
+		ApacheConfig:   "Header always set Content-Security-Policy \"default-src 'self';\"",
+		CWE:            "CWE-693",
+		OWASP:          "A05:2021 Security Misconfiguration",
+		ASVS:           "V14.4.3",
+		DocURL:         "https://developer.mozilla.org/en-US/docs/Web/HTTP/Headers/Content-Security-Policy-Report-Only",
+	},
+}
@@ -0,0 +1,29 @@
+package rules
+
+import (
+	_ "embed"
+	"encoding/json"
+)
+
+//go:embed csp_bypasses.json
+var cspBypassData []byte
+
+// CSPBypass describes a host, optionally limited to a path, that can be abused
+// to execute script when it is allowlisted in a CSP.
+type CSPBypass struct {
+	Host        string `json:"host"` // may start with "*." to match any subdomain
+	Path        string `json:"path,omitempty"`
+	Type        string `json:"type"` // jsonp / angular / user-content
+	Description string `json:"description"`
+}
+
+// CSPBypasses contains the known bypass gadgets shipped with HeaderSentinel.
+var CSPBypasses = loadCSPBypasses()
+
+func loadCSPBypasses() []CSPBypass {
+	bypasses := []CSPBypass{}
+	if err := json.Unmarshal(cspBypassData, &bypasses); err != nil {
+		panic("rules: invalid embedded csp_bypasses.json: " + err.Error())
+	}
+	return bypasses
+}
@@ -0,0 +1,204 @@
+package rules
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Condition types supported by declarative rules.
+const (
+	ConditionRequired  = "required"
+	ConditionForbidden = "forbidden"
+	ConditionEquals    = "equals"
+	ConditionOneOf     = "one-of"
+	ConditionRegex     = "regex"
+	ConditionMin       = "min"
+)
+
+// Condition is the requirement a declarative rule places on a header, or on
+// one directive of it when Directive is set.
+type Condition struct {
+	Type      string   `json:"type"`
+	Directive string   `json:"directive,omitempty"`
+	Value     any      `json:"value,omitempty"`
+	Values    []string `json:"values,omitempty"`
+
+	Pattern *regexp.Regexp `json:"-"`
+	Minimum float64        `json:"-"`
+}
+
+// CustomRule is a rule loaded from a YAML or JSON rule file. It carries the
+// same metadata as SecurityRule.
+type CustomRule struct {
+	ID             string    `json:"id,omitempty"`
+	Header         string    `json:"header"`
+	Name           string    `json:"name"`
+	Risk           RiskLevel `json:"risk"`
+	Description    string    `json:"description"`
+	Recommendation string    `json:"recommendation"`
+	Exploit        string    `json:"exploit,omitempty"`
+	NginxConfig    string    `json:"nginx_config,omitempty"`
+	ApacheConfig   string    `json:"apache_config,omitempty"`
+	CWE            string    `json:"cwe,omitempty"`
+	OWASP          string    `json:"owasp,omitempty"`
+	ASVS           string    `json:"asvs,omitempty"`
+	DocURL         string    `json:"doc_url,omitempty"`
+	Condition      Condition `json:"condition"`
+}
+
+// SecurityRule returns the metadata of a custom rule as a SecurityRule.
+func (r CustomRule) SecurityRule() SecurityRule {
+	return SecurityRule{
+		ID:             r.ID,
+		Header:         r.Header,
+		CheckName:      r.Name,
+		Risk:           r.Risk,
+		Description:    r.Description,
+		Recommendation: r.Recommendation,
+		Exploit:        r.Exploit,
+		NginxConfig:    r.NginxConfig,
+		ApacheConfig:   r.ApacheConfig,
+		CWE:            r.CWE,
+		OWASP:          r.OWASP,
+		ASVS:           r.ASVS,
+		DocURL:         r.DocURL,
+	}
+}
+
+// ValueString returns the condition value as a string. YAML and JSON numbers
+// are both accepted.
+func (c Condition) ValueString() string {
+	switch v := c.Value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	}
+	return fmt.Sprint(c.Value)
+}
+
+// customRuleSlug matches the runs of characters replaced when deriving a rule
+// ID from a rule name.
+var customRuleSlug = regexp.MustCompile(`[^A-Z0-9]+`)
+
+// ruleFile is the top-level layout of a rule file.
+type ruleFile struct {
+	Rules []CustomRule `json:"rules"`
+}
+
+// LoadCustomRules loads declarative rules from a file, or from every .json,
+// .yaml and .yml file in a directory, in name order.
+func LoadCustomRules(path string) ([]CustomRule, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	files := []string{path}
+	if info.IsDir() {
+		entries, err := os.ReadDir(path)
+		if err != nil {
+			return nil, err
+		}
+		files = files[:0]
+		for _, entry := range entries {
+			switch strings.ToLower(filepath.Ext(entry.Name())) {
+			case ".json", ".yaml", ".yml":
+				if !entry.IsDir() {
+					files = append(files, filepath.Join(path, entry.Name()))
+				}
+			}
+		}
+		sort.Strings(files)
+	}
+
+	loaded := []CustomRule{}
+	for _, file := range files {
+		list, err := loadRuleFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", file, err)
+		}
+		loaded = append(loaded, list...)
+	}
+	return loaded, nil
+}
+
+func loadRuleFile(path string) ([]CustomRule, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	if ext := strings.ToLower(filepath.Ext(path)); ext == ".yaml" || ext == ".yml" {
+		doc, err := parseYAML(data)
+		if err != nil {
+			return nil, err
+		}
+		if data, err = json.Marshal(doc); err != nil {
+			return nil, err
+		}
+	}
+
+	file := ruleFile{}
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, err
+	}
+	for i := range file.Rules {
+		if err := file.Rules[i].validate(); err != nil {
+			return nil, fmt.Errorf("rule %d (%s): %w", i+1, file.Rules[i].Name, err)
+		}
+	}
+	return file.Rules, nil
+}
+
+// validate checks a rule and precompiles its condition.
+func (r *CustomRule) validate() error {
+	if r.Header == "" || r.Name == "" {
+		return fmt.Errorf("header and name are required")
+	}
+	if r.ID == "" {
+		r.ID = "CUSTOM-" + strings.Trim(customRuleSlug.ReplaceAllString(strings.ToUpper(r.Name), "-"), "-")
+	}
+	r.Risk = RiskLevel(strings.ToUpper(string(r.Risk)))
+	switch r.Risk {
+	case RiskCritical, RiskHigh, RiskMedium, RiskLow, RiskInfo:
+	case "":
+		r.Risk = RiskMedium
+	default:
+		return fmt.Errorf("unknown risk %q", r.Risk)
+	}
+
+	c := &r.Condition
+	switch c.Type {
+	case ConditionRequired, ConditionForbidden:
+	case ConditionEquals:
+		if c.ValueString() == "" {
+			return fmt.Errorf("equals needs a value")
+		}
+	case ConditionOneOf:
+		if len(c.Values) == 0 {
+			return fmt.Errorf("one-of needs values")
+		}
+	case ConditionRegex:
+		re, err := regexp.Compile(c.ValueString())
+		if err != nil {
+			return err
+		}
+		c.Pattern = re
+	case ConditionMin:
+		n, err := strconv.ParseFloat(c.ValueString(), 64)
+		if err != nil {
+			return fmt.Errorf("min needs a numeric value")
+		}
+		c.Minimum = n
+	default:
+		return fmt.Errorf("unknown condition type %q", c.Type)
+	}
+	return nil
+}
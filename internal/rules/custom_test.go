@@ -0,0 +1,80 @@
+package rules
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLoadCustomRules(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"a.yaml": `rules:
+  - header: X-Frame-Options
+    name: Org XFO
+    risk: high
+    description: Framing must be denied.
+    recommendation: Send DENY.
+    nginx_config: |
+      # deny framing everywhere
+      add_header X-Frame-Options "DENY" always;
+    condition: {type: one-of, values: [DENY, SAMEORIGIN]}
+`,
+		"b.json":    `{"rules": [{"id": "ORG-HSTS", "header": "Strict-Transport-Security", "name": "HSTS age", "condition": {"type": "min", "directive": "max-age", "value": 63072000}}]}`,
+		"notes.txt": "ignored",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	loaded, err := LoadCustomRules(dir)
+	if err != nil {
+		t.Fatalf("LoadCustomRules: %v", err)
+	}
+	if len(loaded) != 2 {
+		t.Fatalf("loaded %d rules, want 2", len(loaded))
+	}
+
+	xfo := loaded[0]
+	if xfo.ID != "CUSTOM-ORG-XFO" || xfo.Risk != RiskHigh {
+		t.Errorf("a.yaml rule = %s %s, want CUSTOM-ORG-XFO HIGH", xfo.ID, xfo.Risk)
+	}
+	if got := strings.Join(xfo.Condition.Values, ","); got != "DENY,SAMEORIGIN" {
+		t.Errorf("one-of values = %q", got)
+	}
+	if want := "# deny framing everywhere\nadd_header X-Frame-Options \"DENY\" always;\n"; xfo.NginxConfig != want {
+		t.Errorf("nginx_config = %q, want %q", xfo.NginxConfig, want)
+	}
+
+	hsts := loaded[1]
+	if hsts.ID != "ORG-HSTS" || hsts.Risk != RiskMedium || hsts.Condition.Minimum != 63072000 {
+		t.Errorf("b.json rule = %s %s %v", hsts.ID, hsts.Risk, hsts.Condition.Minimum)
+	}
+}
+
+func TestLoadCustomRulesInvalid(t *testing.T) {
+	tests := []struct {
+		name, doc, want string
+	}{
+		{"missing name", `{"rules": [{"header": "X"}]}`, "header and name are required"},
+		{"unknown risk", `{"rules": [{"header": "X", "name": "n", "risk": "severe", "condition": {"type": "required"}}]}`, "unknown risk"},
+		{"unknown condition", `{"rules": [{"header": "X", "name": "n", "condition": {"type": "contains"}}]}`, "unknown condition type"},
+		{"bad regex", `{"rules": [{"header": "X", "name": "n", "condition": {"type": "regex", "value": "("}}]}`, "missing closing"},
+		{"non-numeric min", `{"rules": [{"header": "X", "name": "n", "condition": {"type": "min", "value": "soon"}}]}`, "numeric value"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "rules.json")
+			if err := os.WriteFile(path, []byte(tt.doc), 0o644); err != nil {
+				t.Fatal(err)
+			}
+			_, err := LoadCustomRules(path)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("LoadCustomRules error = %v, want it to contain %q", err, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,53 @@
+package rules
+
+// DeprecatedHeaders contains legacy security headers that modern browsers
+// ignore or that have been replaced.
+var DeprecatedHeaders = []SecurityRule{
+	{
+		ID:             "HS-DEP-001",
+		Header:         "X-XSS-Protection",
+		CheckName:      "Deprecated X-XSS-Protection",
+		Risk:           RiskLow,
+		Description:    "The XSS Auditor was removed from all modern browsers. Where it still exists, enabling it can be abused to leak information or to selectively disable scripts.",
+		Recommendation: "Set X-XSS-Protection: 0 (or remove it) and rely on a Content-Security-Policy instead.",
+		Exploit:        "XS-Leaks and script disabling through the XSS filter in legacy browsers.",
+		NginxConfig:    "add_header X-XSS-Protection \"0\" always;",
+		ApacheConfig:   "Header always set X-XSS-Protection \"0\"",
+		CWE:            "CWE-693",
+		OWASP:          "A05:2021 Security Misconfiguration",
+		DocURL:         "https://developer.mozilla.org/en-US/docs/Web/HTTP/Headers/X-XSS-Protection",
+	},
+	{
+		ID:             "HS-DEP-002",
+		Header:         "Expect-CT",
+		CheckName:      "Deprecated Expect-CT",
+		Risk:           RiskInfo,
+		Description:    "Expect-CT is obsolete. Browsers enforce Certificate Transparency for all publicly trusted certificates and ignore the header.",
+		Recommendation: "Remove the Expect-CT header.",
+		CWE:            "CWE-693",
+		OWASP:          "A05:2021 Security Misconfiguration",
+		DocURL:         "https://developer.mozilla.org/en-US/docs/Web/HTTP/Headers/Expect-CT",
+	},
+	{
+		ID:             "HS-DEP-003",
+		Header:         "Public-Key-Pins",
+		CheckName:      "Deprecated Public-Key-Pins",
+		Risk:           RiskLow,
+		Description:    "HTTP Public Key Pinning was removed from browsers because a wrong pin could lock users out of the site.",
+		Recommendation: "Remove Public-Key-Pins and monitor certificate issuance with Certificate Transparency and CAA records instead.",
+		CWE:            "CWE-693",
+		OWASP:          "A05:2021 Security Misconfiguration",
+		DocURL:         "https://developer.mozilla.org/en-US/docs/Glossary/HPKP",
+	},
+	{
+		ID:             "HS-DEP-004",
+		Header:         "Feature-Policy",
+		CheckName:      "Deprecated Feature-Policy",
+		Risk:           RiskInfo,
+		Description:    "Feature-Policy has been replaced by Permissions-Policy, which uses a different syntax.",
+		Recommendation: "Move the policy to a Permissions-Policy header, e.g. camera=(), microphone=().",
+		CWE:            "CWE-693",
+		OWASP:          "A05:2021 Security Misconfiguration",
+		DocURL:         "https://developer.mozilla.org/en-US/docs/Web/HTTP/Headers/Permissions-Policy",
+	},
+}
@@ -0,0 +1,144 @@
+package rules
+
+// DisclosureHeaders contains response headers, besides Server and
+// X-Powered-By, that reveal the technology stack or internal details. The risk
+// of product headers depends on the version exposed and is set by the scanner;
+// the risk below applies to headers that never carry a product version.
+var DisclosureHeaders = []SecurityRule{
+	{
+		ID:             "HS-DISC-003",
+		Header:         "X-AspNet-Version",
+		CheckName:      "Information Disclosure (X-AspNet-Version)",
+		Risk:           RiskLow,
+		Description:    "The X-AspNet-Version header reveals the exact .NET runtime version.",
+		Recommendation: "Set <httpRuntime enableVersionHeader=\"false\" /> in web.config.",
+		Exploit:        "Identifying vulnerable framework versions for targeted attacks.",
+		CWE:            "CWE-497",
+		OWASP:          "A05:2021 Security Misconfiguration",
+		ASVS:           "V14.3.3",
+	},
+	{
+		ID:             "HS-DISC-004",
+		Header:         "X-AspNetMvc-Version",
+		CheckName:      "Information Disclosure (X-AspNetMvc-Version)",
+		Risk:           RiskLow,
+		Description:    "The X-AspNetMvc-Version header reveals the ASP.NET MVC version.",
+		Recommendation: "Set MvcHandler.DisableMvcResponseHeader = true at application start.",
+		Exploit:        "Identifying vulnerable framework versions for targeted attacks.",
+		CWE:            "CWE-497",
+		OWASP:          "A05:2021 Security Misconfiguration",
+		ASVS:           "V14.3.3",
+	},
+	{
+		ID:             "HS-DISC-005",
+		Header:         "X-Generator",
+		CheckName:      "Information Disclosure (X-Generator)",
+		Risk:           RiskLow,
+		Description:    "The X-Generator header reveals the CMS or site generator in use.",
+		Recommendation: "Remove the X-Generator header.",
+		Exploit:        "Identifying the CMS for targeted plugin and core exploits.",
+		CWE:            "CWE-497",
+		OWASP:          "A05:2021 Security Misconfiguration",
+		ASVS:           "V14.3.3",
+	},
+	{
+		ID:             "HS-DISC-006",
+		Header:         "X-Drupal-Cache",
+		CheckName:      "Information Disclosure (X-Drupal-Cache)",
+		Risk:           RiskInfo,
+		Description:    "The X-Drupal-Cache header reveals that the site runs Drupal.",
+		Recommendation: "Strip Drupal cache headers at the reverse proxy.",
+		CWE:            "CWE-497",
+		OWASP:          "A05:2021 Security Misconfiguration",
+		ASVS:           "V14.3.3",
+	},
+	{
+		ID:             "HS-DISC-007",
+		Header:         "X-Mod-Pagespeed",
+		CheckName:      "Information Disclosure (X-Mod-Pagespeed)",
+		Risk:           RiskLow,
+		Description:    "The X-Mod-Pagespeed header reveals the mod_pagespeed module and its version.",
+		Recommendation: "Set ModPagespeedXHeaderValue to a neutral value or strip the header.",
+		CWE:            "CWE-497",
+		OWASP:          "A05:2021 Security Misconfiguration",
+		ASVS:           "V14.3.3",
+	},
+	{
+		ID:             "HS-DISC-008",
+		Header:         "X-Runtime",
+		CheckName:      "Information Disclosure (X-Runtime)",
+		Risk:           RiskInfo,
+		Description:    "The X-Runtime header reveals a Ruby/Rack backend and its request processing time, which helps timing attacks.",
+		Recommendation: "Remove the Rack::Runtime middleware in production.",
+		Exploit:        "Timing side channels such as user enumeration.",
+		CWE:            "CWE-497",
+		OWASP:          "A05:2021 Security Misconfiguration",
+		ASVS:           "V14.3.3",
+	},
+	{
+		ID:             "HS-DISC-009",
+		Header:         "X-Backend-Server",
+		CheckName:      "Information Disclosure (X-Backend-Server)",
+		Risk:           RiskLow,
+		Description:    "The X-Backend-Server header reveals the internal host that served the request.",
+		Recommendation: "Strip the header at the load balancer or reverse proxy.",
+		Exploit:        "Mapping internal infrastructure for lateral movement or SSRF.",
+		CWE:            "CWE-497",
+		OWASP:          "A05:2021 Security Misconfiguration",
+		ASVS:           "V14.3.3",
+	},
+	{
+		ID:             "HS-DISC-010",
+		Header:         "Via",
+		CheckName:      "Information Disclosure (Via)",
+		Risk:           RiskInfo,
+		Description:    "The Via header reveals the proxies and caches in front of the origin.",
+		Recommendation: "Configure proxies to omit the Via header or use a pseudonym.",
+		CWE:            "CWE-497",
+		OWASP:          "A05:2021 Security Misconfiguration",
+		ASVS:           "V14.3.3",
+		DocURL:         "https://developer.mozilla.org/en-US/docs/Web/HTTP/Headers/Via",
+	},
+	{
+		ID:             "HS-DISC-011",
+		Header:         "X-Debug-Token",
+		CheckName:      "Information Disclosure (X-Debug-Token)",
+		Risk:           RiskMedium,
+		Description:    "The X-Debug-Token header shows that the Symfony profiler is enabled, which exposes request data, configuration and secrets.",
+		Recommendation: "Disable the profiler and debug mode in production.",
+		Exploit:        "Reading environment variables, credentials and session data from the profiler.",
+		CWE:            "CWE-489",
+		OWASP:          "A05:2021 Security Misconfiguration",
+		ASVS:           "V14.3.2",
+	},
+	{
+		ID:             "HS-DISC-012",
+		Header:         "X-Debug-Token-Link",
+		CheckName:      "Information Disclosure (X-Debug-Token-Link)",
+		Risk:           RiskMedium,
+		Description:    "The X-Debug-Token-Link header links to the Symfony profiler, which exposes request data, configuration and secrets.",
+		Recommendation: "Disable the profiler and debug mode in production.",
+		Exploit:        "Reading environment variables, credentials and session data from the profiler.",
+		CWE:            "CWE-489",
+		OWASP:          "A05:2021 Security Misconfiguration",
+		ASVS:           "V14.3.2",
+	},
+}
+
+// VersionHeaderProducts maps headers whose value is a bare version number to the
+// product they describe.
+var VersionHeaderProducts = map[string]string{
+	"X-AspNet-Version":    "ASP.NET",
+	"X-AspNetMvc-Version": "ASP.NET MVC",
+	"X-Mod-Pagespeed":     "mod_pagespeed",
+}
+
+// FixedRiskHeaders lists disclosure headers whose risk does not depend on a
+// product version.
+var FixedRiskHeaders = map[string]bool{
+	"X-Runtime":          true,
+	"X-Backend-Server":   true,
+	"X-Debug-Token":      true,
+	"X-Debug-Token-Link": true,
+	"X-Drupal-Cache":     true,
+}
@@ -0,0 +1,85 @@
+package rules
+
+import (
+	_ "embed"
+	"encoding/json"
+	"os"
+	"regexp"
+)
+
+//go:embed fingerprints.json
+var fingerprintData []byte
+
+// Fingerprint identifies a technology from response header signatures. The
+// first capture group of a header pattern, if any, is the version.
+type Fingerprint struct {
+	Name      string            `json:"name"`
+	Category  string            `json:"category"`
+	Headers   map[string]string `json:"headers,omitempty"` // header name -> case-insensitive pattern
+	Cookies   []string          `json:"cookies,omitempty"` // Set-Cookie names that imply the technology
+	EOLBefore string            `json:"eol_before,omitempty"`
+
+	Patterns map[string]*regexp.Regexp `json:"-"`
+}
+
+// Fingerprints contains the technology signatures shipped with HeaderSentinel.
+var Fingerprints = loadFingerprints()
+
+func loadFingerprints() []Fingerprint {
+	fingerprints := []Fingerprint{}
+	if err := json.Unmarshal(fingerprintData, &fingerprints); err != nil {
+		panic("rules: invalid embedded fingerprints.json: " + err.Error())
+	}
+	for i := range fingerprints {
+		fingerprints[i].Patterns = map[string]*regexp.Regexp{}
+		for header, pattern := range fingerprints[i].Headers {
+			re, err := regexp.Compile("(?i)" + pattern)
+			if err != nil {
+				panic("rules: invalid pattern for " + fingerprints[i].Name + " in fingerprints.json: " + err.Error())
+			}
+			fingerprints[i].Patterns[header] = re
+		}
+	}
+	return fingerprints
+}
+
+// Vulnerability is an entry of a user-supplied vulnerability feed. Versions
+// from Introduced (inclusive) up to Fixed (exclusive) are affected; an empty
+// bound is open.
+type Vulnerability struct {
+	ID         string    `json:"id"`
+	Product    string    `json:"product"` // matches Fingerprint.Name, case-insensitive
+	Introduced string    `json:"introduced,omitempty"`
+	Fixed      string    `json:"fixed,omitempty"`
+	Severity   RiskLevel `json:"severity,omitempty"`
+	Summary    string    `json:"summary,omitempty"`
+}
+
+// LoadVulnerabilities reads a JSON array of vulnerabilities from a local file.
+func LoadVulnerabilities(path string) ([]Vulnerability, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	vulns := []Vulnerability{}
+	if err := json.Unmarshal(data, &vulns); err != nil {
+		return nil, err
+	}
+	return vulns, nil
+}
+
+// FingerprintRules contains the checks applied to identified technologies.
+var FingerprintRules = []SecurityRule{
+	{
+		ID:             "HS-TECH-001",
+		CheckName:      "Outdated Technology",
+		Risk:           RiskMedium,
+		Description:    "A disclosed technology version is end-of-life or affected by known vulnerabilities.",
+		Recommendation: "Upgrade to a supported, patched release and stop disclosing version numbers in response headers.",
+		Exploit:        "Exploiting known CVEs of the disclosed version.",
+		CWE:            "CWE-1104",
+		OWASP:          "A06:2021 Vulnerable and Outdated Components",
+		ASVS:           "V14.2.1",
+		DocURL:         "https://owasp.org/Top10/A06_2021-Vulnerable_and_Outdated_Components/",
+	},
+}
@@ -0,0 +1,81 @@
+package rules
+
+// HSTSRules contains the directive-level checks applied to Strict-Transport-Security.
+var HSTSRules = []SecurityRule{
+	{
+		ID:             "HS-HSTS-002",
+		Header:         "Strict-Transport-Security",
+		CheckName:      "HSTS Missing max-age",
+		Risk:           RiskMedium,
+		Description:    "The max-age directive is missing or invalid, so browsers ignore the header entirely.",
+		Recommendation: "Set max-age to at least 31536000 (one year).",
+		Exploit:        "Man-in-the-Middle (MITM) attacks, SSL stripping.",
+		NginxConfig:    "add_header Strict-Transport-Security \"max-age=31536000; includeSubDomains; preload\" always;",
+		ApacheConfig:   "Header always set Strict-Transport-Security \"max-age=31536000; includeSubDomains; preload\"",
+		CWE:            "CWE-319",
+		OWASP:          "A02:2021 Cryptographic Failures",
+		ASVS:           "V14.4.5",
+		DocURL:         "https://developer.mozilla.org/en-US/docs/Web/HTTP/Headers/Strict-Transport-Security",
+	},
+	{
+		ID:             "HS-HSTS-003",
+		Header:         "Strict-Transport-Security",
+		CheckName:      "HSTS Short max-age",
+		Risk:           RiskLow,
+		Description:    "The max-age is shorter than one year, leaving a window where returning visitors can be downgraded to HTTP.",
+		Recommendation: "Increase max-age to at least 31536000 (one year).",
+		Exploit:        "SSL stripping once the HSTS entry has expired.",
+		CWE:            "CWE-319",
+		OWASP:          "A02:2021 Cryptographic Failures",
+		ASVS:           "V14.4.5",
+		DocURL:         "https://developer.mozilla.org/en-US/docs/Web/HTTP/Headers/Strict-Transport-Security",
+	},
+	{
+		ID:             "HS-HSTS-004",
+		Header:         "Strict-Transport-Security",
+		CheckName:      "HSTS Missing includeSubDomains",
+		Risk:           RiskLow,
+		Description:    "The includeSubDomains directive is not set, so subdomains can still be reached over plain HTTP.",
+		Recommendation: "Add includeSubDomains once every subdomain is served over HTTPS.",
+		Exploit:        "Cookie injection or theft through an insecure subdomain.",
+		CWE:            "CWE-319",
+		OWASP:          "A02:2021 Cryptographic Failures",
+		ASVS:           "V14.4.5",
+		DocURL:         "https://developer.mozilla.org/en-US/docs/Web/HTTP/Headers/Strict-Transport-Security",
+	},
+	{
+		ID:             "HS-HSTS-005",
+		Header:         "Strict-Transport-Security",
+		CheckName:      "HSTS Missing preload",
+		Risk:           RiskInfo,
+		Description:    "The preload directive is not set, so first visits are not protected by the browser preload list.",
+		Recommendation: "Add preload and submit the domain to hstspreload.org once it is eligible.",
+		CWE:            "CWE-319",
+		OWASP:          "A02:2021 Cryptographic Failures",
+		DocURL:         "https://hstspreload.org/",
+	},
+	{
+		ID:             "HS-HSTS-006",
+		Header:         "Strict-Transport-Security",
+		CheckName:      "HSTS Duplicate Directive",
+		Risk:           RiskMedium,
+		Description:    "A directive appears more than once. RFC 6797 requires browsers to ignore such a header.",
+		Recommendation: "Send each HSTS directive only once.",
+		Exploit:        "Man-in-the-Middle (MITM) attacks, SSL stripping.",
+		CWE:            "CWE-693",
+		OWASP:          "A05:2021 Security Misconfiguration",
+		ASVS:           "V14.4.5",
+		DocURL:         "https://datatracker.ietf.org/doc/html/rfc6797#section-6.1",
+	},
+	{
+		ID:             "HS-HSTS-007",
+		Header:         "Strict-Transport-Security",
+		CheckName:      "HSTS Preload Ineligible",
+		Risk:           RiskLow,
+		Description:    "The header asks for preloading, but the site does not meet the hstspreload.org submission requirements.",
+		Recommendation: "Serve HSTS on the apex domain with max-age >= 31536000, includeSubDomains and preload, and redirect HTTP to HTTPS on the same host first.",
+		CWE:            "CWE-319",
+		OWASP:          "A02:2021 Cryptographic Failures",
+		DocURL:         "https://hstspreload.org/",
+	},
+}
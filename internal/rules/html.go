@@ -0,0 +1,85 @@
+package rules
+
+// HTMLRules contains the checks applied to the HTML body of a scanned page.
+var HTMLRules = []SecurityRule{
+	{
+		ID:             "HS-SRI-001",
+		Header:         "HTML",
+		CheckName:      "Missing Subresource Integrity",
+		Risk:           RiskMedium,
+		Description:    "A cross-origin script or stylesheet is loaded without an integrity attribute, so a compromised CDN or third party can change what runs on the page.",
+		Recommendation: "Add integrity=\"sha384-...\" and crossorigin=\"anonymous\" to third-party <script> and <link rel=stylesheet> tags.",
+		Exploit:        "Supply-chain XSS through a compromised third-party host.",
+		CWE:            "CWE-353",
+		OWASP:          "A08:2021 Software and Data Integrity Failures",
+		ASVS:           "V14.2.3",
+		DocURL:         "https://developer.mozilla.org/en-US/docs/Web/Security/Subresource_Integrity",
+	},
+	{
+		ID:             "HS-SRI-002",
+		Header:         "HTML",
+		CheckName:      "SRI Missing crossorigin",
+		Risk:           RiskLow,
+		Description:    "A cross-origin resource has an integrity attribute but no crossorigin attribute, so the browser cannot verify it and blocks the resource.",
+		Recommendation: "Add crossorigin=\"anonymous\" next to the integrity attribute.",
+		CWE:            "CWE-353",
+		OWASP:          "A08:2021 Software and Data Integrity Failures",
+		ASVS:           "V14.2.3",
+		DocURL:         "https://developer.mozilla.org/en-US/docs/Web/Security/Subresource_Integrity",
+	},
+	{
+		ID:             "HS-SRI-003",
+		Header:         "HTML",
+		CheckName:      "Weak SRI Hash",
+		Risk:           RiskMedium,
+		Description:    "The integrity attribute has no sha256, sha384 or sha512 hash. Browsers ignore other algorithms, so the resource is not verified.",
+		Recommendation: "Use a sha384 or sha512 integrity hash.",
+		Exploit:        "Supply-chain XSS through a compromised third-party host.",
+		CWE:            "CWE-328",
+		OWASP:          "A08:2021 Software and Data Integrity Failures",
+		ASVS:           "V14.2.3",
+		DocURL:         "https://developer.mozilla.org/en-US/docs/Web/Security/Subresource_Integrity",
+	},
+	{
+		ID:             "HS-MIX-001",
+		Header:         "HTML",
+		CheckName:      "Active Mixed Content",
+		Risk:           RiskMedium,
+		Description:    "An HTTPS page loads scripts, stylesheets, frames or plugins over plain HTTP. Browsers block them, and where they load a network attacker controls the page.",
+		Recommendation: "Load every subresource over https://, or add upgrade-insecure-requests to the CSP.",
+		Exploit:        "Man-in-the-Middle script injection into an HTTPS page.",
+		NginxConfig:    "add_header Content-Security-Policy \"upgrade-insecure-requests;\" always;",
+		ApacheConfig:   "Header always set Content-Security-Policy \"upgrade-insecure-requests;\"",
+		CWE:            "CWE-319",
+		OWASP:          "A02:2021 Cryptographic Failures",
+		ASVS:           "V9.1.1",
+		DocURL:         "https://developer.mozilla.org/en-US/docs/Web/Security/Mixed_content",
+	},
+	{
+		ID:             "HS-MIX-002",
+		Header:         "HTML",
+		CheckName:      "Passive Mixed Content",
+		Risk:           RiskLow,
+		Description:    "An HTTPS page loads images or media over plain HTTP, which a network attacker can observe or replace.",
+		Recommendation: "Load every subresource over https://, or add upgrade-insecure-requests to the CSP.",
+		NginxConfig:    "add_header Content-Security-Policy \"upgrade-insecure-requests;\" always;",
+		ApacheConfig:   "Header always set Content-Security-Policy \"upgrade-insecure-requests;\"",
+		CWE:            "CWE-319",
+		OWASP:          "A02:2021 Cryptographic Failures",
+		ASVS:           "V9.1.1",
+		DocURL:         "https://developer.mozilla.org/en-US/docs/Web/Security/Mixed_content",
+	},
+	{
+		ID:             "HS-MIX-003",
+		Header:         "HTML",
+		CheckName:      "Insecure Form Action",
+		Risk:           RiskMedium,
+		Description:    "A form on an HTTPS page submits to an http:// URL, sending its fields in clear text.",
+		Recommendation: "Submit forms to https:// URLs.",
+		Exploit:        "Credential and data interception over plain HTTP.",
+		CWE:            "CWE-319",
+		OWASP:          "A02:2021 Cryptographic Failures",
+		ASVS:           "V9.1.1",
+		DocURL:         "https://developer.mozilla.org/en-US/docs/Web/Security/Mixed_content",
+	},
+}
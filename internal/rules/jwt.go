@@ -0,0 +1,58 @@
+package rules
+
+// JWTRules contains the checks applied to JSON Web Tokens found in cookies and
+// bearer tokens.
+var JWTRules = []SecurityRule{
+	{
+		ID:             "HS-JWT-001",
+		Header:         "Set-Cookie",
+		CheckName:      "JWT alg none",
+		Risk:           RiskCritical,
+		Description:    "The token declares the 'none' algorithm and carries no signature, so anyone can forge its claims.",
+		Recommendation: "Sign tokens with HS256/RS256/ES256 and reject unsigned tokens on the server.",
+		Exploit:        "Authentication bypass and privilege escalation with forged tokens.",
+		CWE:            "CWE-347",
+		OWASP:          "A02:2021 Cryptographic Failures",
+		ASVS:           "V3.5.3",
+		DocURL:         "https://datatracker.ietf.org/doc/html/rfc8725",
+	},
+	{
+		ID:             "HS-JWT-002",
+		Header:         "Set-Cookie",
+		CheckName:      "JWT Missing exp",
+		Risk:           RiskMedium,
+		Description:    "The token has no exp claim, so a stolen token never expires.",
+		Recommendation: "Add an exp claim and keep access tokens short-lived.",
+		Exploit:        "Indefinite session hijacking with a stolen token.",
+		CWE:            "CWE-613",
+		OWASP:          "A07:2021 Identification and Authentication Failures",
+		ASVS:           "V3.5.3",
+		DocURL:         "https://datatracker.ietf.org/doc/html/rfc8725",
+	},
+	{
+		ID:             "HS-JWT-003",
+		Header:         "Set-Cookie",
+		CheckName:      "JWT Long-Lived",
+		Risk:           RiskLow,
+		Description:    "The token stays valid for a long time, extending the window in which a stolen token can be replayed.",
+		Recommendation: "Limit access token lifetime to minutes or hours and use refresh tokens for longer sessions.",
+		Exploit:        "Session hijacking with a stolen token.",
+		CWE:            "CWE-613",
+		OWASP:          "A07:2021 Identification and Authentication Failures",
+		ASVS:           "V3.5.3",
+		DocURL:         "https://datatracker.ietf.org/doc/html/rfc8725",
+	},
+	{
+		ID:             "HS-JWT-004",
+		Header:         "Set-Cookie",
+		CheckName:      "JWT Sensitive Claims",
+		Risk:           RiskLow,
+		Description:    "The token payload is only base64-encoded and exposes personal data or authorization details to the client.",
+		Recommendation: "Keep personal data and roles server-side and put only an opaque subject identifier in the token.",
+		Exploit:        "Information disclosure and easier targeting of privileged accounts.",
+		CWE:            "CWE-315",
+		OWASP:          "A02:2021 Cryptographic Failures",
+		ASVS:           "V8.3.4",
+		DocURL:         "https://datatracker.ietf.org/doc/html/rfc8725",
+	},
+}
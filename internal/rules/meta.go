@@ -0,0 +1,66 @@
+package rules
+
+// MetaRules contains the checks applied to policies delivered through
+// <meta http-equiv> tags in the HTML body.
+var MetaRules = []SecurityRule{
+	{
+		ID:             "HS-META-001",
+		Header:         "Content-Security-Policy",
+		CheckName:      "CSP Directive Ignored in Meta",
+		Risk:           RiskLow,
+		Description:    "The directive has no effect when the policy is delivered via <meta http-equiv>, so the protection it appears to give is missing.",
+		Recommendation: "Send the policy as a Content-Security-Policy response header, which supports frame-ancestors, sandbox and reporting.",
+		NginxConfig:    "add_header Content-Security-Policy \"frame-ancestors 'self';\" always;",
+		ApacheConfig:   "Header always set Content-Security-Policy \"frame-ancestors 'self';\"",
+		CWE:            "CWE-693",
+		OWASP:          "A05:2021 Security Misconfiguration",
+		ASVS:           "V14.4.3",
+		DocURL:         "https://developer.mozilla.org/en-US/docs/Web/HTML/Element/meta#http-equiv",
+	},
+	{
+		ID:             "HS-META-002",
+		Header:         "Refresh",
+		CheckName:      "Insecure Meta Refresh",
+		Risk:           RiskMedium,
+		Description:    "A <meta http-equiv=\"refresh\"> tag redirects an HTTPS page to plain HTTP.",
+		Recommendation: "Redirect to an https:// URL, preferably with a 301/308 response instead of a meta refresh.",
+		Exploit:        "SSL stripping and Man-in-the-Middle (MITM) attacks after the redirect.",
+		CWE:            "CWE-319",
+		OWASP:          "A02:2021 Cryptographic Failures",
+		ASVS:           "V9.1.1",
+		DocURL:         "https://developer.mozilla.org/en-US/docs/Web/HTML/Element/meta#http-equiv",
+	},
+	{
+		ID:             "HS-META-003",
+		Header:         "Refresh",
+		CheckName:      "Meta Refresh Redirect",
+		Risk:           RiskInfo,
+		Description:    "The page redirects with a <meta http-equiv=\"refresh\"> tag, which is not visible in the HTTP redirect chain.",
+		Recommendation: "Prefer HTTP redirects so clients and security tools see the redirect.",
+		DocURL:         "https://developer.mozilla.org/en-US/docs/Web/HTML/Element/meta#http-equiv",
+	},
+	{
+		ID:             "HS-META-004",
+		Header:         "Content-Security-Policy",
+		CheckName:      "CSP Delivered via Meta",
+		Risk:           RiskInfo,
+		Description:    "A Content-Security-Policy is delivered via <meta http-equiv>. It is enforced, but only from the point the tag is parsed and without frame-ancestors, sandbox or reporting.",
+		Recommendation: "Send the policy as a Content-Security-Policy response header.",
+		NginxConfig:    "add_header Content-Security-Policy \"default-src 'self';\" always;",
+		ApacheConfig:   "Header always set Content-Security-Policy \"default-src 'self';\"",
+		ASVS:           "V14.4.3",
+		DocURL:         "https://developer.mozilla.org/en-US/docs/Web/HTML/Element/meta#http-equiv",
+	},
+	{
+		ID:             "HS-META-005",
+		Header:         "Referrer-Policy",
+		CheckName:      "Referrer Policy Delivered via Meta",
+		Risk:           RiskInfo,
+		Description:    "A referrer policy is delivered via <meta name=\"referrer\">. It applies to the document, but not to the request that loaded it or to non-HTML responses.",
+		Recommendation: "Send the policy as a Referrer-Policy response header.",
+		NginxConfig:    "add_header Referrer-Policy \"strict-origin-when-cross-origin\" always;",
+		ApacheConfig:   "Header always set Referrer-Policy \"strict-origin-when-cross-origin\"",
+		ASVS:           "V14.4.6",
+		DocURL:         "https://developer.mozilla.org/en-US/docs/Web/HTML/Element/meta/name/referrer",
+	},
+}
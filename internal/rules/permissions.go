@@ -0,0 +1,69 @@
+package rules
+
+// PowerfulFeatures lists the Permissions-Policy features that expose sensitive
+// device or payment APIs.
+var PowerfulFeatures = []string{"camera", "microphone", "geolocation", "payment", "usb"}
+
+// PermissionsPolicyRules contains the checks applied to Permissions-Policy.
+var PermissionsPolicyRules = []SecurityRule{
+	{
+		ID:             "HS-PP-002",
+		Header:         "Permissions-Policy",
+		CheckName:      "Invalid Permissions-Policy",
+		Risk:           RiskMedium,
+		Description:    "The header is not a valid structured-field dictionary, so browsers ignore it entirely.",
+		Recommendation: "Use the structured-field syntax, e.g. camera=(), geolocation=(self \"https://maps.example.com\").",
+		NginxConfig:    "add_header Permissions-Policy \"camera=(), microphone=(), geolocation=(), payment=(), usb=()\" always;",
+		ApacheConfig:   "Header always set Permissions-Policy \"camera=(), microphone=(), geolocation=(), payment=(), usb=()\"",
+		CWE:            "CWE-693",
+		OWASP:          "A05:2021 Security Misconfiguration",
+		DocURL:         "https://developer.mozilla.org/en-US/docs/Web/HTTP/Headers/Permissions-Policy",
+	},
+	{
+		ID:             "HS-PP-003",
+		Header:         "Permissions-Policy",
+		CheckName:      "Legacy Feature-Policy Syntax",
+		Risk:           RiskMedium,
+		Description:    "The header uses the old Feature-Policy syntax ('camera 'none'; ...'), which Permissions-Policy does not understand.",
+		Recommendation: "Rewrite the policy in the structured-field syntax, e.g. camera=(), microphone=().",
+		NginxConfig:    "add_header Permissions-Policy \"camera=(), microphone=(), geolocation=(), payment=(), usb=()\" always;",
+		ApacheConfig:   "Header always set Permissions-Policy \"camera=(), microphone=(), geolocation=(), payment=(), usb=()\"",
+		CWE:            "CWE-693",
+		OWASP:          "A05:2021 Security Misconfiguration",
+		DocURL:         "https://developer.mozilla.org/en-US/docs/Web/HTTP/Headers/Permissions-Policy",
+	},
+	{
+		ID:             "HS-PP-004",
+		Header:         "Permissions-Policy",
+		CheckName:      "Powerful Feature Allowed to All Origins",
+		Risk:           RiskMedium,
+		Description:    "A powerful feature is allowed for every origin, including third-party iframes.",
+		Recommendation: "Restrict the feature to self or an explicit list of origins, or disable it with ().",
+		Exploit:        "Embedded third-party content accessing the camera, microphone, location or payment APIs.",
+		CWE:            "CWE-250",
+		OWASP:          "A05:2021 Security Misconfiguration",
+		DocURL:         "https://developer.mozilla.org/en-US/docs/Web/HTTP/Headers/Permissions-Policy",
+	},
+	{
+		ID:             "HS-PP-005",
+		Header:         "Permissions-Policy",
+		CheckName:      "Powerful Feature Left at Default",
+		Risk:           RiskLow,
+		Description:    "Powerful features are not listed in the policy, so the browser default (usually self) applies.",
+		Recommendation: "Disable unused features explicitly, e.g. camera=(), microphone=().",
+		CWE:            "CWE-250",
+		OWASP:          "A05:2021 Security Misconfiguration",
+		DocURL:         "https://developer.mozilla.org/en-US/docs/Web/HTTP/Headers/Permissions-Policy",
+	},
+	{
+		ID:             "HS-PP-006",
+		Header:         "Permissions-Policy",
+		CheckName:      "Required Feature Not Disabled",
+		Risk:           RiskMedium,
+		Description:    "A feature that must be disabled by organisation policy is not set to ().",
+		Recommendation: "Set the feature to an empty allowlist, e.g. camera=().",
+		CWE:            "CWE-250",
+		OWASP:          "A05:2021 Security Misconfiguration",
+		DocURL:         "https://developer.mozilla.org/en-US/docs/Web/HTTP/Headers/Permissions-Policy",
+	},
+}
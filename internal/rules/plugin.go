@@ -0,0 +1,14 @@
+package rules
+
+// PluginRules contains the findings reported about external plugins themselves.
+var PluginRules = []SecurityRule{
+	{
+		ID:             "HS-PLUGIN-001",
+		Header:         "Plugin",
+		CheckName:      "Plugin Failure",
+		Risk:           RiskInfo,
+		Description:    "An external plugin failed, timed out or returned invalid output, so its checks were not applied to this target.",
+		Recommendation: "Run the plugin by hand with the same JSON input and fix the error it reports.",
+		DocURL:         "https://github.com/ismailtsdln/HeaderSentinel#plugins",
+	},
+}
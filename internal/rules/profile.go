@@ -0,0 +1,128 @@
+package rules
+
+import "strings"
+
+// Profile tailors the rule set to a kind of application. Disabling a rule from
+// SecurityHeaders turns off every check of its header.
+type Profile struct {
+	Name        string
+	Description string
+	Disabled    []string             // rule IDs; a trailing * matches an ID prefix
+	Risks       map[string]RiskLevel // adjusted risk by rule ID
+}
+
+// Profiles contains the built-in rule profiles.
+var Profiles = []Profile{
+	{
+		Name:        "owasp-baseline",
+		Description: "The headers recommended by the OWASP Secure Headers Project, without HSTS preloading or opt-in isolation.",
+		Disabled: []string{
+			"HS-HSTS-005", "HS-HSTS-007", // preload list submission is a separate decision
+			"HS-ISO-002", // COEP breaks third-party embeds that do not opt in
+			"HS-CACHE-004", "HS-META-003", "HS-DEP-002", "HS-DEP-004",
+		},
+		Risks: map[string]RiskLevel{
+			"HS-XCTO-001": RiskMedium,
+		},
+	},
+	{
+		Name:        "strict",
+		Description: "Every rule, with raised severities for defence-in-depth headers and weak policies.",
+		Risks: map[string]RiskLevel{
+			"HS-HSTS-003": RiskMedium,
+			"HS-HSTS-004": RiskMedium,
+			"HS-HSTS-005": RiskLow,
+			"HS-CSP-005":  RiskMedium,
+			"HS-CSP-007":  RiskHigh,
+			"HS-CSP-008":  RiskHigh,
+			"HS-XCTO-001": RiskMedium,
+			"HS-REF-001":  RiskMedium,
+			"HS-PP-001":   RiskMedium,
+			"HS-ISO-001":  RiskMedium,
+			"HS-ISO-002":  RiskMedium,
+			"HS-ISO-003":  RiskMedium,
+			"HS-DISC-001": RiskMedium,
+			"HS-DISC-002": RiskMedium,
+			"HS-COOK-004": RiskMedium,
+			"HS-SRI-001":  RiskHigh,
+			"HS-MIX-002":  RiskMedium,
+		},
+	},
+	{
+		Name:        "api",
+		Description: "JSON APIs that are never rendered as documents: framing, feature, referrer and HTML checks are off.",
+		Disabled: []string{
+			"HS-XFO-*", "HS-PP-*", "HS-REF-*", "HS-ISO-001", "HS-ISO-002",
+			"HS-SRI-*", "HS-MIX-*", "HS-META-*", "HS-CT-002",
+		},
+		Risks: map[string]RiskLevel{
+			"HS-CSP-001":   RiskLow,
+			"HS-XCTO-001":  RiskMedium,
+			"HS-CT-003":    RiskHigh,
+			"HS-CACHE-002": RiskHigh,
+			"HS-CORS-006":  RiskMedium,
+		},
+	},
+	{
+		Name:        "static-site",
+		Description: "Static sites without sessions or APIs: token, authenticated caching and preflight checks are off, third-party content is held to a higher bar.",
+		Disabled: []string{
+			"HS-JWT-*", "HS-CACHE-002", "HS-CORS-006", "HS-ISO-002", "HS-ISO-003",
+		},
+		Risks: map[string]RiskLevel{
+			"HS-HSTS-004": RiskMedium,
+			"HS-SRI-001":  RiskHigh,
+			"HS-MIX-001":  RiskHigh,
+		},
+	},
+}
+
+// FindProfile returns the built-in profile with the given name.
+func FindProfile(name string) (Profile, bool) {
+	for _, profile := range Profiles {
+		if strings.EqualFold(profile.Name, name) {
+			return profile, true
+		}
+	}
+	return Profile{}, false
+}
+
+// ProfileNames returns the names of the built-in profiles.
+func ProfileNames() []string {
+	names := []string{}
+	for _, profile := range Profiles {
+		names = append(names, profile.Name)
+	}
+	return names
+}
+
+// Enabled reports whether a rule is enabled in the profile.
+func (p Profile) Enabled(id string) bool {
+	for _, pattern := range p.Disabled {
+		if prefix, ok := strings.CutSuffix(pattern, "*"); ok && strings.HasPrefix(id, prefix) || pattern == id {
+			return false
+		}
+	}
+	return true
+}
+
+// Risk returns the risk of a rule in the profile.
+func (p Profile) Risk(id string, risk RiskLevel) RiskLevel {
+	if adjusted, ok := p.Risks[id]; ok {
+		return adjusted
+	}
+	return risk
+}
+
+// Apply returns a copy of list without the disabled rules and with adjusted risks.
+func (p Profile) Apply(list []SecurityRule) []SecurityRule {
+	applied := []SecurityRule{}
+	for _, rule := range list {
+		if !p.Enabled(rule.ID) {
+			continue
+		}
+		rule.Risk = p.Risk(rule.ID, rule.Risk)
+		applied = append(applied, rule)
+	}
+	return applied
+}
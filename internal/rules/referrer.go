@@ -0,0 +1,47 @@
+package rules
+
+// ReferrerPolicyRules contains the value checks applied to Referrer-Policy.
+var ReferrerPolicyRules = []SecurityRule{
+	{
+		ID:             "HS-REF-002",
+		Header:         "Referrer-Policy",
+		CheckName:      "Unsafe Referrer Policy",
+		Risk:           RiskMedium,
+		Description:    "The effective policy sends the full URL, including path and query string, to every origin and over plain HTTP.",
+		Recommendation: "Use 'strict-origin-when-cross-origin' or 'no-referrer'.",
+		Exploit:        "Leaking tokens, session IDs or personal data in URLs to third parties.",
+		NginxConfig:    "add_header Referrer-Policy \"strict-origin-when-cross-origin\" always;",
+		ApacheConfig:   "Header always set Referrer-Policy \"strict-origin-when-cross-origin\"",
+		CWE:            "CWE-200",
+		OWASP:          "A05:2021 Security Misconfiguration",
+		ASVS:           "V14.4.6",
+		DocURL:         "https://developer.mozilla.org/en-US/docs/Web/HTTP/Headers/Referrer-Policy",
+	},
+	{
+		ID:             "HS-REF-003",
+		Header:         "Referrer-Policy",
+		CheckName:      "Weak Referrer Policy",
+		Risk:           RiskLow,
+		Description:    "The effective policy sends the full URL to other origins, or the origin over plain HTTP.",
+		Recommendation: "Use 'strict-origin-when-cross-origin' or 'no-referrer'.",
+		Exploit:        "Information disclosure via Referer header.",
+		NginxConfig:    "add_header Referrer-Policy \"strict-origin-when-cross-origin\" always;",
+		ApacheConfig:   "Header always set Referrer-Policy \"strict-origin-when-cross-origin\"",
+		CWE:            "CWE-200",
+		OWASP:          "A05:2021 Security Misconfiguration",
+		ASVS:           "V14.4.6",
+		DocURL:         "https://developer.mozilla.org/en-US/docs/Web/HTTP/Headers/Referrer-Policy",
+	},
+	{
+		ID:             "HS-REF-004",
+		Header:         "Referrer-Policy",
+		CheckName:      "Unrecognised Referrer Policy",
+		Risk:           RiskInfo,
+		Description:    "None of the listed policies is recognised, so browsers ignore the header and apply their default 'strict-origin-when-cross-origin'.",
+		Recommendation: "Set a valid policy such as 'strict-origin-when-cross-origin' explicitly.",
+		CWE:            "CWE-200",
+		OWASP:          "A05:2021 Security Misconfiguration",
+		ASVS:           "V14.4.6",
+		DocURL:         "https://developer.mozilla.org/en-US/docs/Web/HTTP/Headers/Referrer-Policy",
+	},
+}
@@ -13,6 +13,7 @@ const (
 
 // SecurityRule defines a header security check.
 type SecurityRule struct {
+	ID             string // stable identifier, e.g. HS-HSTS-001
 	Header         string
 	CheckName      string
 	Risk           RiskLevel
@@ -21,11 +22,18 @@ type SecurityRule struct {
 	Exploit        string
 	NginxConfig    string
 	ApacheConfig   string
+	Optional       bool // absence of the header is not a finding
+
+	CWE    string // e.g. CWE-319
+	OWASP  string // OWASP Top 10 category, e.g. A05:2021 Security Misconfiguration
+	ASVS   string // OWASP ASVS 4.0 requirement, e.g. V14.4.5
+	DocURL string
 }
 
 // SecurityHeaders contains the list of rules to check.
 var SecurityHeaders = []SecurityRule{
 	{
+		ID:             "HS-CSP-001",
 		Header:         "Content-Security-Policy",
 		CheckName:      "Insecure CSP",
 		Risk:           RiskHigh,
@@ -34,8 +42,13 @@ var SecurityHeaders = []SecurityRule{
 		Exploit:        "XSS, Clickjacking, Data injection.",
 		NginxConfig:    "add_header Content-Security-Policy \"default-src 'self';\";",
 		ApacheConfig:   "Header set Content-Security-Policy \"default-src 'self';\"",
+		CWE:            "CWE-693",
+		OWASP:          "A05:2021 Security Misconfiguration",
+		ASVS:           "V14.4.3",
+		DocURL:         "https://developer.mozilla.org/en-US/docs/Web/HTTP/Headers/Content-Security-Policy",
 	},
 	{
+		ID:             "HS-HSTS-001",
 		Header:         "Strict-Transport-Security",
 		CheckName:      "Missing HSTS",
 		Risk:           RiskMedium,
@@ -44,8 +57,13 @@ var SecurityHeaders = []SecurityRule{
 		Exploit:        "Man-in-the-Middle (MITM) attacks, SSL stripping.",
 		NginxConfig:    "add_header Strict-Transport-Security \"max-age=31536000; includeSubDomains; preload\" always;",
 		ApacheConfig:   "Header always set Strict-Transport-Security \"max-age=31536000; includeSubDomains; preload\"",
+		CWE:            "CWE-319",
+		OWASP:          "A02:2021 Cryptographic Failures",
+		ASVS:           "V14.4.5",
+		DocURL:         "https://developer.mozilla.org/en-US/docs/Web/HTTP/Headers/Strict-Transport-Security",
 	},
 	{
+		ID:             "HS-XFO-001",
 		Header:         "X-Frame-Options",
 		CheckName:      "Missing XFO",
 		Risk:           RiskMedium,
@@ -54,8 +72,13 @@ var SecurityHeaders = []SecurityRule{
 		Exploit:        "Clickjacking.",
 		NginxConfig:    "add_header X-Frame-Options \"SAMEORIGIN\" always;",
 		ApacheConfig:   "Header always set X-Frame-Options \"SAMEORIGIN\"",
+		CWE:            "CWE-1021",
+		OWASP:          "A05:2021 Security Misconfiguration",
+		ASVS:           "V14.4.7",
+		DocURL:         "https://developer.mozilla.org/en-US/docs/Web/HTTP/Headers/X-Frame-Options",
 	},
 	{
+		ID:             "HS-XCTO-001",
 		Header:         "X-Content-Type-Options",
 		CheckName:      "Missing XCTO",
 		Risk:           RiskLow,
@@ -64,85 +87,182 @@ var SecurityHeaders = []SecurityRule{
 		Exploit:        "MIME-sniffing based attacks.",
 		NginxConfig:    "add_header X-Content-Type-Options \"nosniff\" always;",
 		ApacheConfig:   "Header always set X-Content-Type-Options \"nosniff\"",
+		CWE:            "CWE-693",
+		OWASP:          "A05:2021 Security Misconfiguration",
+		ASVS:           "V14.4.4",
+		DocURL:         "https://developer.mozilla.org/en-US/docs/Web/HTTP/Headers/X-Content-Type-Options",
 	},
 	{
+		ID:             "HS-REF-001",
 		Header:         "Referrer-Policy",
 		CheckName:      "Insecure Referrer Policy",
 		Risk:           RiskLow,
 		Description:    "The Referrer-Policy HTTP header controls how much referrer information (sent via the Referer header) should be included with requests.",
 		Recommendation: "Use a safer policy like 'strict-origin-when-cross-origin' or 'no-referrer'.",
 		Exploit:        "Information disclosure via Referer header.",
+		CWE:            "CWE-200",
+		OWASP:          "A05:2021 Security Misconfiguration",
+		ASVS:           "V14.4.6",
+		DocURL:         "https://developer.mozilla.org/en-US/docs/Web/HTTP/Headers/Referrer-Policy",
 	},
 	{
+		ID:             "HS-PP-001",
 		Header:         "Permissions-Policy",
 		CheckName:      "Missing Permissions Policy",
 		Risk:           RiskLow,
 		Description:    "Permissions-Policy allows developers to selectively enable, disable, and modify the behavior of certain APIs and web features in the browser.",
 		Recommendation: "Implement a restrictive Permissions-Policy to reduce attack surface.",
 		Exploit:        "Unauthorized access to browser APIs (camera, geolocation, etc.).",
+		CWE:            "CWE-693",
+		OWASP:          "A05:2021 Security Misconfiguration",
+		DocURL:         "https://developer.mozilla.org/en-US/docs/Web/HTTP/Headers/Permissions-Policy",
 	},
 	{
+		ID:             "HS-DISC-001",
 		Header:         "Server",
 		CheckName:      "Information Disclosure (Server)",
 		Risk:           RiskLow,
 		Description:    "The Server header contains information about the software used by the origin server to handle the request.",
 		Recommendation: "Configure the server to remove or minimize the Server header.",
 		Exploit:        "Banner grabbing, identifying vulnerable server versions.",
+		Optional:       true,
+		CWE:            "CWE-497",
+		OWASP:          "A05:2021 Security Misconfiguration",
+		ASVS:           "V14.3.3",
+		DocURL:         "https://developer.mozilla.org/en-US/docs/Web/HTTP/Headers/Server",
 	},
 	{
+		ID:             "HS-DISC-002",
 		Header:         "X-Powered-By",
 		CheckName:      "Information Disclosure (X-Powered-By)",
 		Risk:           RiskLow,
 		Description:    "The X-Powered-By header provides information about the technology used (e.g., PHP, ASP.NET).",
 		Recommendation: "Remove the X-Powered-By header.",
 		Exploit:        "Identifying backend technology stack for targeted attacks.",
+		Optional:       true,
+		CWE:            "CWE-497",
+		OWASP:          "A05:2021 Security Misconfiguration",
+		ASVS:           "V14.3.3",
+		DocURL:         "https://owasp.org/www-project-secure-headers/#x-powered-by",
 	},
 	{
+		ID:             "HS-ISO-001",
 		Header:         "Cross-Origin-Opener-Policy",
 		CheckName:      "Insecure COOP",
 		Risk:           RiskLow,
 		Description:    "COOP helps to isolate your document from other origin's documents to prevent certain types of attacks like Spectre.",
 		Recommendation: "Set COOP to 'same-origin'.",
 		Exploit:        "Spectre-style attacks, cross-window information leaks.",
+		CWE:            "CWE-693",
+		OWASP:          "A05:2021 Security Misconfiguration",
+		DocURL:         "https://developer.mozilla.org/en-US/docs/Web/HTTP/Headers/Cross-Origin-Opener-Policy",
 	},
 	{
+		ID:             "HS-ISO-002",
 		Header:         "Cross-Origin-Embedder-Policy",
 		CheckName:      "Insecure COEP",
 		Risk:           RiskLow,
 		Description:    "COEP prevents a document from loading any cross-origin resources that do not explicitly grant the document permission.",
 		Recommendation: "Set COEP to 'require-corp' or 'credentialless'.",
 		Exploit:        "Loading unauthorized cross-origin resources.",
+		CWE:            "CWE-693",
+		OWASP:          "A05:2021 Security Misconfiguration",
+		DocURL:         "https://developer.mozilla.org/en-US/docs/Web/HTTP/Headers/Cross-Origin-Embedder-Policy",
 	},
 	{
+		ID:             "HS-ISO-003",
 		Header:         "Cross-Origin-Resource-Policy",
 		CheckName:      "Insecure CORP",
 		Risk:           RiskLow,
 		Description:    "CORP allows you to control which origins can load your resources.",
 		Recommendation: "Set CORP to 'same-origin' or 'same-site'.",
 		Exploit:        "Speculative side-channel attacks (e.g., Spectre).",
+		CWE:            "CWE-693",
+		OWASP:          "A05:2021 Security Misconfiguration",
+		DocURL:         "https://developer.mozilla.org/en-US/docs/Web/HTTP/Headers/Cross-Origin-Resource-Policy",
 	},
 	{
+		ID:             "HS-COOK-001",
 		Header:         "Set-Cookie",
-		CheckName:      "Insecure Cookie (Missing HttpOnly)",
+		CheckName:      "Insecure Cookie",
 		Risk:           RiskMedium,
-		Description:    "The HttpOnly flag help to prevent XSS attacks from stealing cookies.",
-		Recommendation: "Add the 'HttpOnly' flag to all sensitive cookies.",
-		Exploit:        "Cookie theft via XSS.",
+		Description:    "Cookies without the right attributes can be stolen or abused across sites.",
+		Recommendation: "Set HttpOnly, Secure and SameSite on all sensitive cookies.",
+		Exploit:        "Cookie theft via XSS, interception over insecure connections, CSRF.",
+		Optional:       true,
+		CWE:            "CWE-614",
+		OWASP:          "A05:2021 Security Misconfiguration",
+		ASVS:           "V3.4.1",
+		DocURL:         "https://developer.mozilla.org/en-US/docs/Web/HTTP/Headers/Set-Cookie",
 	},
+}
+
+// HeaderValueRules contains the checks for invalid values of headers whose
+// absence is covered by SecurityHeaders.
+var HeaderValueRules = []SecurityRule{
 	{
-		Header:         "Set-Cookie",
-		CheckName:      "Insecure Cookie (Missing Secure)",
+		ID:             "HS-XFO-002",
+		Header:         "X-Frame-Options",
+		CheckName:      "Invalid X-Frame-Options",
 		Risk:           RiskMedium,
-		Description:    "The Secure flag ensures that the cookie is only sent over HTTPS.",
-		Recommendation: "Add the 'Secure' flag to all sensitive cookies.",
-		Exploit:        "Cookie interception over insecure connections (MITM).",
+		Description:    "X-Frame-Options is set to a value other than DENY or SAMEORIGIN, which browsers ignore, so the page can still be framed.",
+		Recommendation: "Use 'DENY' or 'SAMEORIGIN', or frame-ancestors in Content-Security-Policy for finer control.",
+		Exploit:        "Clickjacking.",
+		NginxConfig:    "add_header X-Frame-Options \"SAMEORIGIN\" always;",
+		ApacheConfig:   "Header always set X-Frame-Options \"SAMEORIGIN\"",
+		CWE:            "CWE-1021",
+		OWASP:          "A05:2021 Security Misconfiguration",
+		ASVS:           "V14.4.7",
+		DocURL:         "https://developer.mozilla.org/en-US/docs/Web/HTTP/Headers/X-Frame-Options",
 	},
 	{
-		Header:         "Set-Cookie",
-		CheckName:      "Insecure Cookie (Missing SameSite)",
+		ID:             "HS-XCTO-002",
+		Header:         "X-Content-Type-Options",
+		CheckName:      "Invalid X-Content-Type-Options",
 		Risk:           RiskLow,
-		Description:    "The SameSite flag helps to protect against CSRF attacks.",
-		Recommendation: "Add 'SameSite=Lax' or 'SameSite=Strict' to your cookies.",
-		Exploit:        "Cross-Site Request Forgery (CSRF).",
+		Description:    "X-Content-Type-Options is set to a value other than nosniff, which browsers ignore, so MIME sniffing stays enabled.",
+		Recommendation: "Set X-Content-Type-Options to 'nosniff'.",
+		Exploit:        "MIME-sniffing based attacks.",
+		NginxConfig:    "add_header X-Content-Type-Options \"nosniff\" always;",
+		ApacheConfig:   "Header always set X-Content-Type-Options \"nosniff\"",
+		CWE:            "CWE-693",
+		OWASP:          "A05:2021 Security Misconfiguration",
+		ASVS:           "V14.4.4",
+		DocURL:         "https://developer.mozilla.org/en-US/docs/Web/HTTP/Headers/X-Content-Type-Options",
 	},
 }
+
+// FindRule returns the rule in list with the given check name.
+func FindRule(list []SecurityRule, checkName string) SecurityRule {
+	for _, rule := range list {
+		if rule.CheckName == checkName {
+			return rule
+		}
+	}
+	return SecurityRule{CheckName: checkName}
+}
+
+// AllRules returns every built-in rule.
+func AllRules() []SecurityRule {
+	lists := [][]SecurityRule{
+		SecurityHeaders, HeaderValueRules, CSPRules, HSTSRules, ReferrerPolicyRules,
+		PermissionsPolicyRules, DisclosureHeaders, CookieRules, JWTRules, CORSRules,
+		CacheRules, DeprecatedHeaders, FingerprintRules, ContentTypeRules, MetaRules,
+		HTMLRules, PluginRules,
+	}
+	all := []SecurityRule{}
+	for _, list := range lists {
+		all = append(all, list...)
+	}
+	return all
+}
+
+// FindRuleByID returns the built-in rule with the given ID.
+func FindRuleByID(id string) (SecurityRule, bool) {
+	for _, rule := range AllRules() {
+		if rule.ID == id {
+			return rule, true
+		}
+	}
+	return SecurityRule{}, false
+}
@@ -0,0 +1,477 @@
+package rules
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// yamlLine is a non-blank, non-comment line of a YAML document.
+type yamlLine struct {
+	num    int
+	indent int
+	text   string
+}
+
+// yamlParser decodes the subset of YAML used by rule files: block mappings and
+// sequences, flow sequences and mappings, quoted and plain scalars, and
+// literal (|) or folded (>) block scalars with optional chomping indicators.
+// Scalars are returned as strings and null as nil, so the result can be
+// re-encoded as JSON and decoded into typed structs. Tags, anchors, aliases,
+// complex keys, directives and multiple documents are rejected.
+type yamlParser struct {
+	lines []yamlLine
+	pos   int
+}
+
+func parseYAML(data []byte) (any, error) {
+	p := &yamlParser{}
+	// Lines indented deeper than block belong to a block scalar and are kept
+	// verbatim, including blank lines and lines starting with "#"
+	block := -1
+	text := strings.TrimSuffix(strings.ReplaceAll(string(data), "\r\n", "\n"), "\n")
+	for i, raw := range strings.Split(text, "\n") {
+		text := strings.TrimLeft(raw, " ")
+		indent := len(raw) - len(text)
+		trimmed := strings.TrimSpace(raw)
+		if block >= 0 {
+			switch {
+			case trimmed == "":
+				p.lines = append(p.lines, yamlLine{num: i + 1, indent: block + 1})
+				continue
+			case indent > block:
+				p.lines = append(p.lines, yamlLine{num: i + 1, indent: indent, text: strings.TrimRight(text, " ")})
+				continue
+			}
+			block = -1
+		}
+
+		switch {
+		case trimmed == "" || strings.HasPrefix(trimmed, "#"):
+			continue
+		case trimmed == "---" || trimmed == "...":
+			if len(p.lines) > 0 {
+				return nil, unsupportedYAML(i+1, "multiple documents")
+			}
+			continue
+		case strings.HasPrefix(trimmed, "%"):
+			return nil, unsupportedYAML(i+1, "directives")
+		case strings.HasPrefix(text, "\t"):
+			return nil, fmt.Errorf("yaml: line %d: tabs are not allowed for indentation", i+1)
+		}
+		line := yamlLine{num: i + 1, indent: indent, text: strings.TrimRight(text, " ")}
+		p.lines = append(p.lines, line)
+		if column, ok := blockScalarStart(line); ok {
+			block = column
+		}
+	}
+	if len(p.lines) == 0 {
+		return nil, nil
+	}
+	value, err := p.block(p.lines[0].indent)
+	if err != nil {
+		return nil, err
+	}
+	if p.pos < len(p.lines) {
+		return nil, fmt.Errorf("yaml: line %d: unexpected indentation", p.lines[p.pos].num)
+	}
+	return value, nil
+}
+
+func unsupportedYAML(num int, feature string) error {
+	return fmt.Errorf("yaml: line %d: unsupported YAML feature: %s", num, feature)
+}
+
+func (p *yamlParser) block(indent int) (any, error) {
+	if isSequenceItem(p.lines[p.pos].text) {
+		return p.sequence(indent)
+	}
+	return p.mapping(indent)
+}
+
+func isSequenceItem(text string) bool {
+	return text == "-" || strings.HasPrefix(text, "- ")
+}
+
+func (p *yamlParser) sequence(indent int) (any, error) {
+	items := []any{}
+	for p.pos < len(p.lines) && p.lines[p.pos].indent == indent && isSequenceItem(p.lines[p.pos].text) {
+		line := p.lines[p.pos]
+		content := strings.TrimSpace(strings.TrimPrefix(line.text, "-"))
+
+		switch {
+		case content == "":
+			p.pos++
+			if p.pos >= len(p.lines) || p.lines[p.pos].indent <= indent {
+				items = append(items, nil)
+				continue
+			}
+			value, err := p.block(p.lines[p.pos].indent)
+			if err != nil {
+				return nil, err
+			}
+			items = append(items, value)
+		case isBlockScalarHeader(stripYAMLComment(content)):
+			p.pos++
+			value, err := p.blockScalar(indent, stripYAMLComment(content), line.num)
+			if err != nil {
+				return nil, err
+			}
+			items = append(items, value)
+		case yamlKeyEnd(content) >= 0 || isSequenceItem(content) || strings.HasPrefix(content, "? "):
+			// "- key: value" starts a nested block at the column of its content
+			p.lines[p.pos] = yamlLine{num: line.num, indent: line.indent + len(line.text) - len(content), text: content}
+			value, err := p.block(p.lines[p.pos].indent)
+			if err != nil {
+				return nil, err
+			}
+			items = append(items, value)
+		default:
+			value, err := yamlScalar(stripYAMLComment(content), line.num)
+			if err != nil {
+				return nil, err
+			}
+			items = append(items, value)
+			p.pos++
+		}
+	}
+	return items, nil
+}
+
+func (p *yamlParser) mapping(indent int) (any, error) {
+	m := map[string]any{}
+	for p.pos < len(p.lines) && p.lines[p.pos].indent == indent && !isSequenceItem(p.lines[p.pos].text) {
+		line := p.lines[p.pos]
+		if line.text == "?" || strings.HasPrefix(line.text, "? ") {
+			return nil, unsupportedYAML(line.num, "complex keys")
+		}
+		end := yamlKeyEnd(line.text)
+		if end < 0 {
+			return nil, fmt.Errorf("yaml: line %d: expected \"key: value\"", line.num)
+		}
+		key, err := yamlKey(line.text[:end], line.num)
+		if err != nil {
+			return nil, err
+		}
+		rest := stripYAMLComment(line.text[end+1:])
+		p.pos++
+
+		var value any
+		switch {
+		case isBlockScalarHeader(rest):
+			if value, err = p.blockScalar(indent, rest, line.num); err != nil {
+				return nil, err
+			}
+		case rest != "":
+			if value, err = yamlScalar(rest, line.num); err != nil {
+				return nil, err
+			}
+		case p.pos < len(p.lines) && p.lines[p.pos].indent > indent:
+			if value, err = p.block(p.lines[p.pos].indent); err != nil {
+				return nil, err
+			}
+		case p.pos < len(p.lines) && p.lines[p.pos].indent == indent && isSequenceItem(p.lines[p.pos].text):
+			// A sequence may sit at the same indentation as its key
+			if value, err = p.sequence(indent); err != nil {
+				return nil, err
+			}
+		}
+		m[key] = value
+	}
+	return m, nil
+}
+
+// isBlockScalarHeader reports whether text introduces a block scalar, such as
+// "|", ">-" or "|2+".
+func isBlockScalarHeader(text string) bool {
+	return text != "" && (text[0] == '|' || text[0] == '>') && strings.Trim(text[1:], "+-0123456789") == ""
+}
+
+// blockScalarStart reports whether a line opens a block scalar, either as the
+// value of a "key: |" entry or as a "- |" sequence item, and returns the
+// column the scalar's lines must be indented beyond.
+func blockScalarStart(line yamlLine) (int, bool) {
+	column, content := line.indent, line.text
+	for isSequenceItem(content) {
+		rest := strings.TrimSpace(strings.TrimPrefix(content, "-"))
+		if isBlockScalarHeader(stripYAMLComment(rest)) {
+			return column, true
+		}
+		column += len(content) - len(rest)
+		content = rest
+	}
+	end := yamlKeyEnd(content)
+	if end < 0 {
+		return 0, false
+	}
+	return column, isBlockScalarHeader(stripYAMLComment(content[end+1:]))
+}
+
+// blockScalar collects the lines indented deeper than indent, keeping their
+// indentation relative to the least indented one. Literal scalars keep line
+// breaks, folded scalars join lines with spaces and turn blank lines into
+// line breaks. The final line break is kept once by default, dropped with the
+// "-" chomping indicator and kept with trailing blank lines with "+".
+func (p *yamlParser) blockScalar(indent int, header string, num int) (string, error) {
+	folded, chomp := header[0] == '>', byte(0)
+	for i := 1; i < len(header); i++ {
+		switch c := header[i]; {
+		case (c == '-' || c == '+') && chomp == 0:
+			chomp = c
+		case c >= '0' && c <= '9':
+			return "", unsupportedYAML(num, "block indentation indicators")
+		default:
+			return "", fmt.Errorf("yaml: line %d: invalid block scalar header %q", num, header)
+		}
+	}
+
+	lines := []yamlLine{}
+	for p.pos < len(p.lines) && p.lines[p.pos].indent > indent {
+		lines = append(lines, p.lines[p.pos])
+		p.pos++
+	}
+	trailing := 0
+	for len(lines) > 0 && lines[len(lines)-1].text == "" {
+		lines = lines[:len(lines)-1]
+		trailing++
+	}
+	if len(lines) == 0 {
+		return "", nil
+	}
+
+	base := -1
+	for _, line := range lines {
+		if line.text != "" && (base < 0 || line.indent < base) {
+			base = line.indent
+		}
+	}
+
+	var b strings.Builder
+	for i, line := range lines {
+		text := ""
+		if line.text != "" {
+			text = strings.Repeat(" ", line.indent-base) + line.text
+		}
+		switch {
+		case i == 0:
+		case !folded || text == "":
+			b.WriteByte('\n')
+		case lines[i-1].text != "":
+			b.WriteByte(' ')
+		}
+		b.WriteString(text)
+	}
+	switch chomp {
+	case 0:
+		b.WriteByte('\n')
+	case '+':
+		b.WriteString(strings.Repeat("\n", trailing+1))
+	}
+	return b.String(), nil
+}
+
+// yamlQuoteEnd returns the index of the quote closing the quoted scalar that
+// starts at text[start], or -1. Double-quoted scalars escape with a backslash,
+// single-quoted ones by doubling the quote.
+func yamlQuoteEnd(text string, start int) int {
+	quote := text[start]
+	for i := start + 1; i < len(text); i++ {
+		switch {
+		case quote == '"' && text[i] == '\\':
+			i++
+		case text[i] == quote && quote == '\'' && i+1 < len(text) && text[i+1] == '\'':
+			i++
+		case text[i] == quote:
+			return i
+		}
+	}
+	return -1
+}
+
+// opensQuote reports whether the quote at text[i] starts a quoted scalar,
+// rather than being part of a plain scalar such as "it's".
+func opensQuote(text string, i int) bool {
+	return (text[i] == '"' || text[i] == '\'') && (i == 0 || strings.IndexByte(" [{,:", text[i-1]) >= 0)
+}
+
+// yamlKeyEnd returns the index of the colon that ends a mapping key, or -1.
+func yamlKeyEnd(text string) int {
+	for i := 0; i < len(text); i++ {
+		c := text[i]
+		switch {
+		case i == 0 && opensQuote(text, i):
+			if i = yamlQuoteEnd(text, i); i < 0 {
+				return -1
+			}
+		case c == '#' && i > 0 && text[i-1] == ' ':
+			return -1
+		case c == ':' && (i == len(text)-1 || text[i+1] == ' '):
+			return i
+		}
+	}
+	return -1
+}
+
+// stripYAMLComment removes a trailing " # comment" outside quotes and trims
+// the result.
+func stripYAMLComment(text string) string {
+	for i := 0; i < len(text); i++ {
+		switch {
+		case opensQuote(text, i):
+			if i = yamlQuoteEnd(text, i); i < 0 {
+				return strings.TrimSpace(text)
+			}
+		case text[i] == '#' && (i == 0 || text[i-1] == ' '):
+			return strings.TrimSpace(text[:i])
+		}
+	}
+	return strings.TrimSpace(text)
+}
+
+// yamlKey decodes a mapping key, which must be a plain or quoted scalar.
+func yamlKey(text string, num int) (string, error) {
+	key, err := yamlScalar(strings.TrimSpace(text), num)
+	if err != nil {
+		return "", err
+	}
+	s, ok := key.(string)
+	if !ok {
+		return "", fmt.Errorf("yaml: line %d: mapping keys must be scalars", num)
+	}
+	return s, nil
+}
+
+func yamlScalar(text string, num int) (any, error) {
+	switch {
+	case strings.HasPrefix(text, "["):
+		if !strings.HasSuffix(text, "]") {
+			return nil, fmt.Errorf("yaml: line %d: unterminated flow sequence", num)
+		}
+		items := []any{}
+		inner := strings.TrimSpace(text[1 : len(text)-1])
+		if inner == "" {
+			return items, nil
+		}
+		for _, part := range splitFlow(inner) {
+			item, err := yamlScalar(strings.TrimSpace(part), num)
+			if err != nil {
+				return nil, err
+			}
+			items = append(items, item)
+		}
+		return items, nil
+	case strings.HasPrefix(text, "{"):
+		if !strings.HasSuffix(text, "}") {
+			return nil, fmt.Errorf("yaml: line %d: unterminated flow mapping", num)
+		}
+		m := map[string]any{}
+		inner := strings.TrimSpace(text[1 : len(text)-1])
+		if inner == "" {
+			return m, nil
+		}
+		for _, part := range splitFlow(inner) {
+			part = strings.TrimSpace(part)
+			end := yamlKeyEnd(part)
+			if end < 0 {
+				return nil, fmt.Errorf("yaml: line %d: expected \"key: value\" in flow mapping", num)
+			}
+			key, err := yamlKey(part[:end], num)
+			if err != nil {
+				return nil, err
+			}
+			value, err := yamlScalar(strings.TrimSpace(part[end+1:]), num)
+			if err != nil {
+				return nil, err
+			}
+			m[key] = value
+		}
+		return m, nil
+	case strings.HasPrefix(text, "\""):
+		if yamlQuoteEnd(text, 0) != len(text)-1 {
+			return nil, fmt.Errorf("yaml: line %d: unterminated string", num)
+		}
+		return yamlUnescape(text[1:len(text)-1], num)
+	case strings.HasPrefix(text, "'"):
+		if yamlQuoteEnd(text, 0) != len(text)-1 {
+			return nil, fmt.Errorf("yaml: line %d: unterminated string", num)
+		}
+		return strings.ReplaceAll(text[1:len(text)-1], "''", "'"), nil
+	}
+
+	switch text {
+	case "", "~", "null", "Null", "NULL":
+		return nil, nil
+	}
+	switch text[0] {
+	case '!':
+		return nil, unsupportedYAML(num, "tags")
+	case '&':
+		return nil, unsupportedYAML(num, "anchors")
+	case '*':
+		return nil, unsupportedYAML(num, "aliases (quote a literal \"*\")")
+	}
+	return text, nil
+}
+
+// yamlEscapes maps the single-character escapes of double-quoted scalars.
+var yamlEscapes = map[byte]string{
+	'0': "\x00", 'a': "\a", 'b': "\b", 't': "\t", '\t': "\t", 'n': "\n", 'v': "\v",
+	'f': "\f", 'r': "\r", 'e': "\x1b", ' ': " ", '"': "\"", '/': "/", '\\': "\\",
+	'N': "\u0085", '_': "\u00a0", 'L': "\u2028", 'P': "\u2029",
+}
+
+// yamlUnescape decodes the escape sequences of a double-quoted scalar,
+// including \xXX, \uXXXX and \UXXXXXXXX code points.
+func yamlUnescape(text string, num int) (string, error) {
+	var b strings.Builder
+	for i := 0; i < len(text); i++ {
+		if text[i] != '\\' {
+			b.WriteByte(text[i])
+			continue
+		}
+		i++
+		if i == len(text) {
+			return "", fmt.Errorf("yaml: line %d: invalid escape at end of string", num)
+		}
+		if s, ok := yamlEscapes[text[i]]; ok {
+			b.WriteString(s)
+			continue
+		}
+		size := map[byte]int{'x': 2, 'u': 4, 'U': 8}[text[i]]
+		if size == 0 || i+size >= len(text) {
+			return "", fmt.Errorf("yaml: line %d: invalid escape \\%c", num, text[i])
+		}
+		code, err := strconv.ParseUint(text[i+1:i+1+size], 16, 32)
+		if err != nil {
+			return "", fmt.Errorf("yaml: line %d: invalid escape \\%s", num, text[i:i+1+size])
+		}
+		b.WriteRune(rune(code))
+		i += size
+	}
+	return b.String(), nil
+}
+
+// splitFlow splits the items of a flow collection on commas outside quotes
+// and nested collections.
+func splitFlow(text string) []string {
+	parts := []string{}
+	depth := 0
+	start := 0
+	for i := 0; i < len(text); i++ {
+		c := text[i]
+		switch {
+		case opensQuote(text, i):
+			if i = yamlQuoteEnd(text, i); i < 0 {
+				return append(parts, text[start:])
+			}
+		case c == '[' || c == '{':
+			depth++
+		case c == ']' || c == '}':
+			depth--
+		case c == ',' && depth == 0:
+			parts = append(parts, text[start:i])
+			start = i + 1
+		}
+	}
+	return append(parts, text[start:])
+}
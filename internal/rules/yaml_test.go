@@ -0,0 +1,166 @@
+package rules
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseYAML(t *testing.T) {
+	tests := []struct {
+		name string
+		doc  string
+		want any
+	}{
+		{
+			name: "empty document",
+			doc:  "# only a comment\n---\n",
+			want: nil,
+		},
+		{
+			name: "block mapping with comments",
+			doc:  "---\nheader: X-Frame-Options # trailing comment\nrisk: HIGH\n\n# full-line comment\nname: Org XFO\n",
+			want: map[string]any{"header": "X-Frame-Options", "risk": "HIGH", "name": "Org XFO"},
+		},
+		{
+			name: "sequence of mappings",
+			doc:  "rules:\n  - header: A\n    condition:\n      type: required\n  - header: B\n",
+			want: map[string]any{"rules": []any{
+				map[string]any{"header": "A", "condition": map[string]any{"type": "required"}},
+				map[string]any{"header": "B"},
+			}},
+		},
+		{
+			name: "sequence at the indentation of its key",
+			doc:  "values:\n- a\n- b\nnext: c\n",
+			want: map[string]any{"values": []any{"a", "b"}, "next": "c"},
+		},
+		{
+			name: "nested sequence item",
+			doc:  "- - a\n  - b\n- c\n",
+			want: []any{[]any{"a", "b"}, "c"},
+		},
+		{
+			name: "empty values",
+			doc:  "a:\nb:\n  -\n",
+			want: map[string]any{"a": nil, "b": []any{nil}},
+		},
+		{
+			name: "flow sequence",
+			doc:  "values: [DENY, 'SAME ORIGIN', \"a, b\"]\nnone: []\n",
+			want: map[string]any{"values": []any{"DENY", "SAME ORIGIN", "a, b"}, "none": []any{}},
+		},
+		{
+			name: "flow mapping",
+			doc:  "condition: {type: forbidden, directive: 'max-age'}\nnone: {}\n",
+			want: map[string]any{"condition": map[string]any{"type": "forbidden", "directive": "max-age"}, "none": map[string]any{}},
+		},
+		{
+			name: "flow sequence inside flow mapping",
+			doc:  "condition: {type: one-of, values: [a, b]}\n",
+			want: map[string]any{"condition": map[string]any{"type": "one-of", "values": []any{"a", "b"}}},
+		},
+		{
+			name: "flow mappings inside flow sequence",
+			doc:  "list: [{a: 1}, {b: [2, 3]}]\n",
+			want: map[string]any{"list": []any{map[string]any{"a": "1"}, map[string]any{"b": []any{"2", "3"}}}},
+		},
+		{
+			name: "quoted scalars",
+			doc:  "a: \"x # not a comment\"\nb: 'it''s: fine'\nc: \"line\\nbreak \\\"q\\\"\"\n\"quoted key\": v\n",
+			want: map[string]any{"a": "x # not a comment", "b": "it's: fine", "c": "line\nbreak \"q\"", "quoted key": "v"},
+		},
+		{
+			name: "colon without space stays in scalar",
+			doc:  "url: https://example.com/a\n",
+			want: map[string]any{"url": "https://example.com/a"},
+		},
+		{
+			name: "literal block keeps comments, blank lines and indentation",
+			doc:  "nginx_config: |\n  # hardened headers\n  location / {\n    add_header X-Frame-Options DENY;\n\n  }\n\nnext: x\n",
+			want: map[string]any{"nginx_config": "# hardened headers\nlocation / {\n  add_header X-Frame-Options DENY;\n\n}\n", "next": "x"},
+		},
+		{
+			name: "literal block inside sequence item",
+			doc:  "- name: a\n  apache_config: |\n    # comment\n    Header set X 1\n- name: b\n",
+			want: []any{
+				map[string]any{"name": "a", "apache_config": "# comment\nHeader set X 1\n"},
+				map[string]any{"name": "b"},
+			},
+		},
+		{
+			name: "chomping indicators",
+			doc:  "strip: |-\n  ^DENY$\nfolded: >-\n  a\n  b\nkeep: |+\n  x\n\nclip: |\n  y\n\n\nempty: |\nnext: z\n",
+			want: map[string]any{"strip": "^DENY$", "folded": "a b", "keep": "x\n\n", "clip": "y\n", "empty": "", "next": "z"},
+		},
+		{
+			name: "block scalar as sequence item",
+			doc:  "- |-\n  one\n- - >\n    two\n    lines\n- three\n",
+			want: []any{"one", []any{"two lines\n"}, "three"},
+		},
+		{
+			name: "double-quoted escapes",
+			doc:  "a: \"caf\\u00e9 \\x41\\t\\/\\\\ \\U0001F600\"\nb: \"\\\"# not a comment\\\"\" # comment\n",
+			want: map[string]any{"a": "café A\t/\\ 😀", "b": "\"# not a comment\""},
+		},
+		{
+			name: "null scalars",
+			doc:  "a: ~\nb: null\nc: NULL\nd: 'null'\nitems: [~, x]\n",
+			want: map[string]any{"a": nil, "b": nil, "c": nil, "d": "null", "items": []any{nil, "x"}},
+		},
+		{
+			name: "apostrophe in plain scalar",
+			doc:  "description: it's fine # comment\nlist: [don't, \"a, b\"]\n",
+			want: map[string]any{"description": "it's fine", "list": []any{"don't", "a, b"}},
+		},
+		{
+			name: "folded block",
+			doc:  "description: >\n  first line\n  continues\n\n  second paragraph\n",
+			want: map[string]any{"description": "first line continues\nsecond paragraph\n"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseYAML([]byte(tt.doc))
+			if err != nil {
+				t.Fatalf("parseYAML: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseYAML = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseYAMLErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		doc  string
+		want string
+	}{
+		{"tab indentation", "a:\n\tb: c\n", "tabs are not allowed"},
+		{"missing colon", "a: b\nnot a mapping\n", "expected \"key: value\""},
+		{"unterminated flow sequence", "a: [b, c\n", "unterminated flow sequence"},
+		{"unterminated flow mapping", "a: {b: c\n", "unterminated flow mapping"},
+		{"unterminated string", "a: \"b\n", "unterminated string"},
+		{"flow mapping without colon", "a: {b}\n", "in flow mapping"},
+		{"unexpected indentation", "a: b\n  c: d\n", "unexpected indentation"},
+		{"invalid escape", "a: \"\\q\"\n", "invalid escape"},
+		{"short unicode escape", "a: \"\\u00\"\n", "invalid escape"},
+		{"tag", "a: !!str 1\n", "unsupported YAML feature: tags"},
+		{"anchor", "a: &x 1\n", "unsupported YAML feature: anchors"},
+		{"alias", "a: *\n", "unsupported YAML feature: aliases"},
+		{"complex key", "? a\n: b\n", "unsupported YAML feature: complex keys"},
+		{"indentation indicator", "a: |2\n   x\n", "unsupported YAML feature: block indentation indicators"},
+		{"directive", "%YAML 1.2\n---\na: b\n", "unsupported YAML feature: directives"},
+		{"multiple documents", "a: b\n---\nc: d\n", "unsupported YAML feature: multiple documents"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parseYAML([]byte(tt.doc))
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("parseYAML error = %v, want it to contain %q", err, tt.want)
+			}
+		})
+	}
+}
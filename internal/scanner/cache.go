@@ -0,0 +1,132 @@
+package scanner
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ismailtsdln/HeaderSentinel/internal/rules"
+)
+
+// ParseCacheControl parses a Cache-Control header into a map of lower-case
+// directive names to their (unquoted) values.
+func ParseCacheControl(value string) map[string]string {
+	directives := map[string]string{}
+	for _, part := range strings.Split(value, ",") {
+		name, val, _ := strings.Cut(part, "=")
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" {
+			continue
+		}
+		directives[name] = strings.Trim(strings.TrimSpace(val), "\"")
+	}
+	return directives
+}
+
+// isSharedCacheable reports whether a shared cache such as a CDN may store the
+// response and serve it without revalidation.
+func isSharedCacheable(header http.Header, now time.Time) bool {
+	cc := ParseCacheControl(strings.Join(header.Values("Cache-Control"), ","))
+	for _, name := range []string{"no-store", "private", "no-cache"} {
+		if _, ok := cc[name]; ok {
+			return false
+		}
+	}
+	if _, ok := cc["public"]; ok {
+		return true
+	}
+	for _, name := range []string{"s-maxage", "max-age"} {
+		if v, ok := cc[name]; ok {
+			age, err := strconv.Atoi(v)
+			return err == nil && age > 0
+		}
+	}
+	expires, err := http.ParseTime(header.Get("Expires"))
+	return err == nil && expires.After(now)
+}
+
+// isAuthenticatedResponse reports whether the request that produced a response
+// carried credentials.
+func isAuthenticatedResponse(resp *http.Response) bool {
+	return resp.Request != nil && (resp.Request.Header.Get("Authorization") != "" || resp.Request.Header.Get("Cookie") != "")
+}
+
+// setsSessionCookie reports whether a response issues a likely session cookie.
+// The name is only a heuristic, so it never marks the response as authenticated.
+func setsSessionCookie(resp *http.Response) bool {
+	for _, value := range resp.Header.Values("Set-Cookie") {
+		if cookie, ok := parseSetCookie(value); ok {
+			if class, _ := ClassifyCookie(cookie.Name, cookie.Value); class == CookieSession {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// cacheEvidence describes the headers that show a shared cache already served the response.
+func cacheEvidence(header http.Header) string {
+	evidence := []string{}
+	if age := header.Get("Age"); age != "" && age != "0" {
+		evidence = append(evidence, "Age: "+age)
+	}
+	if via := header.Get("Via"); via != "" {
+		evidence = append(evidence, "Via: "+via)
+	}
+	if len(evidence) == 0 {
+		return ""
+	}
+	return ", served by a cache (" + strings.Join(evidence, ", ") + ")"
+}
+
+func (s *HeaderScanner) analyzeCaching(resp *http.Response, findings *[]Finding) {
+	now := time.Now()
+	if date, err := http.ParseTime(resp.Header.Get("Date")); err == nil {
+		now = date
+	}
+
+	report := func(checkName, status string, risk rules.RiskLevel, detail string) {
+		rule := rules.FindRule(rules.CacheRules, checkName)
+		finding := s.createFinding(rule, status, risk)
+		if detail != "" {
+			finding.Description += " (" + detail + ")"
+		}
+		*findings = append(*findings, finding)
+	}
+
+	cacheControl := strings.Join(resp.Header.Values("Cache-Control"), ",")
+	cc := ParseCacheControl(cacheControl)
+	cacheable := isSharedCacheable(resp.Header, now)
+	authenticated := isAuthenticatedResponse(resp)
+	evidence := cacheEvidence(resp.Header)
+
+	if cacheable && len(resp.Header.Values("Set-Cookie")) > 0 {
+		risk := rules.FindRule(rules.CacheRules, "Cacheable Response Sets Cookies").Risk
+		detail := "Cache-Control: " + cacheControl
+		if authenticated || setsSessionCookie(resp) {
+			// A shared cache would hand the same session cookie to every visitor
+			risk = rules.RiskHigh
+			detail += ", likely session cookie"
+		}
+		report("Cacheable Response Sets Cookies", "misconfigured", risk, detail+evidence)
+	}
+
+	if _, noStore := cc["no-store"]; authenticated && !noStore {
+		detail := "Cache-Control: " + cacheControl
+		if cacheControl == "" {
+			detail = "no Cache-Control header"
+		}
+		report("Authenticated Response Without no-store", "misconfigured", rules.RiskMedium, detail+evidence)
+
+		vary := strings.ToLower(strings.Join(resp.Header.Values("Vary"), ","))
+		if cacheable && !strings.Contains(vary, "cookie") && !strings.Contains(vary, "authorization") && !strings.Contains(vary, "*") {
+			report("Cacheable Response Missing Vary", "misconfigured", rules.RiskLow, "")
+		}
+	}
+
+	if cacheControl == "" && strings.Contains(strings.ToLower(resp.Header.Get("Pragma")), "no-cache") {
+		// Pragma alone still works, it is only noted for information
+		report("Legacy Pragma Cache Control", "present", rules.RiskInfo, "")
+	}
+}
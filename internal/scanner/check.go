@@ -0,0 +1,399 @@
+package scanner
+
+import (
+	"mime"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ismailtsdln/HeaderSentinel/internal/rules"
+)
+
+// Target is everything a check can inspect about one scanned URL.
+type Target struct {
+	Request   *http.Request
+	Response  *http.Response
+	Redirects RedirectResult       // redirect chain that led to Response, if traced
+	Body      []byte               // up to 1 MiB of an HTML body, nil for other content
+	Meta      MetaPolicies         // policies delivered through <meta> tags in Body
+	Rules     []rules.SecurityRule // header rules enabled for this scan
+
+	// Results recorded by the built-in checks besides their findings
+	Technologies []Technology       // technologies identified from headers and cookies
+	Isolation    *IsolationResult   // cross-origin isolation verdict
+	HSTSPreload  *HSTSPreloadResult // preload eligibility, nil unless the probe ran
+}
+
+// NewTarget builds the check input for a response. The response body stays
+// readable for later consumers.
+func NewTarget(resp *http.Response, redirects RedirectResult) *Target {
+	body := readHTMLBody(resp)
+	return &Target{
+		Request:   resp.Request,
+		Response:  resp,
+		Redirects: redirects,
+		Body:      body,
+		Meta:      ParseMetaPolicies(body),
+	}
+}
+
+// Rule returns the enabled rule for a header.
+func (t *Target) Rule(header string) (rules.SecurityRule, bool) {
+	for _, rule := range t.Rules {
+		if strings.EqualFold(rule.Header, header) {
+			return rule, true
+		}
+	}
+	return rules.SecurityRule{}, false
+}
+
+// Check is a single analysis run against every scanned response.
+type Check interface {
+	Name() string
+	Run(target *Target) []Finding
+}
+
+type checkFunc struct {
+	name string
+	run  func(target *Target) []Finding
+}
+
+func (c checkFunc) Name() string                 { return c.name }
+func (c checkFunc) Run(target *Target) []Finding { return c.run(target) }
+
+// NewCheck wraps a function as a Check.
+func NewCheck(name string, run func(target *Target) []Finding) Check {
+	return checkFunc{name: name, run: run}
+}
+
+var (
+	registryMu sync.RWMutex
+	registry   []Check
+)
+
+// RegisterCheck adds a check to the default registry. Scanners created by
+// NewHeaderScanner afterwards run it after the built-in checks; a check with
+// the name of a built-in or earlier registered check replaces it.
+func RegisterCheck(check Check) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	registry = withCheck(registry, check)
+}
+
+// RegisteredChecks returns the checks in the default registry.
+func RegisteredChecks() []Check {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	return append([]Check(nil), registry...)
+}
+
+// Register adds a check to this scanner only, replacing any check of the same name.
+func (s *HeaderScanner) Register(check Check) {
+	s.Checks = withCheck(s.Checks, check)
+}
+
+func withCheck(checks []Check, check Check) []Check {
+	for i, c := range checks {
+		if c.Name() == check.Name() {
+			checks[i] = check
+			return checks
+		}
+	}
+	return append(checks, check)
+}
+
+// builtinChecks returns the checks shipped with the scanner, in report order.
+func (s *HeaderScanner) builtinChecks() []Check {
+	return []Check{
+		NewCheck("csp", s.checkCSP),
+		NewCheck("hsts", s.checkHSTS),
+		NewCheck("x-frame-options", s.checkFrameOptions),
+		NewCheck("x-content-type-options", s.checkContentTypeOptions),
+		NewCheck("referrer-policy", s.checkReferrerPolicy),
+		NewCheck("permissions-policy", s.checkPermissionsPolicy),
+		NewCheck("disclosure", s.checkDisclosure),
+		NewCheck("cross-origin", s.checkCrossOrigin),
+		NewCheck("cookies", s.checkCookies),
+		NewCheck("meta", s.checkMeta),
+		NewCheck("subresource-integrity", s.checkSubresources),
+		NewCheck("mixed-content", s.checkMixedContent),
+		NewCheck("caching", s.checkCaching),
+		NewCheck("content-type", s.checkContentType),
+		NewCheck("deprecated", s.checkDeprecated),
+		NewCheck("technologies", s.checkTechnologies),
+		NewCheck("custom-rules", s.checkCustomRules),
+		NewCheck("bearer-token", s.checkBearerToken),
+		NewCheck("hsts-preload", s.checkHSTSPreload),
+		NewCheck("cors-probe", s.checkCORSProbe),
+	}
+}
+
+// headerValues returns the values of an enabled rule's header. A missing
+// header is reported unless the rule is optional.
+func (s *HeaderScanner) headerValues(t *Target, header string, findings *[]Finding) (rules.SecurityRule, []string) {
+	rule, ok := t.Rule(header)
+	if !ok {
+		return rule, nil
+	}
+	values := t.Response.Header.Values(rule.Header)
+	if len(values) == 0 && !rule.Optional {
+		*findings = append(*findings, s.createFinding(rule, "missing", rule.Risk))
+	}
+	return rule, values
+}
+
+func (s *HeaderScanner) checkCSP(t *Target) []Finding {
+	findings := []Finding{}
+	rule, ok := t.Rule("Content-Security-Policy")
+	if !ok {
+		return findings
+	}
+	header := t.Response.Header
+
+	// Repeated CSP headers are separate policies that must all be evaluated together
+	values := header.Values(rule.Header)
+	switch {
+	case len(t.Meta.CSP) > 0:
+		// Policies delivered via <meta http-equiv> are enforced alongside the header ones
+		s.analyzeMetaCSP(values, t.Meta.CSP, &findings)
+	case len(values) > 0:
+		s.analyzeCSP(strings.Join(values, ","), false, &findings)
+	case header.Get("Content-Security-Policy-Report-Only") != "":
+		// A report-only policy shows the rollout is in progress, but it still blocks nothing
+		notEnforced := rules.FindRule(rules.CSPRules, "CSP Not Enforced")
+		findings = append(findings, s.createFinding(notEnforced, "report-only", notEnforced.Risk))
+	default:
+		findings = append(findings, s.createFinding(rule, "missing", rule.Risk))
+	}
+	if len(values) > 0 {
+		s.analyzeCSPReporting(strings.Join(values, ","), false, header, &findings)
+	}
+
+	if values := header.Values("Content-Security-Policy-Report-Only"); len(values) > 0 {
+		value := strings.Join(values, ",")
+		s.analyzeCSP(value, true, &findings)
+		s.analyzeCSPReporting(value, true, header, &findings)
+	}
+	return findings
+}
+
+func (s *HeaderScanner) checkHSTS(t *Target) []Finding {
+	findings := []Finding{}
+	_, values := s.headerValues(t, "Strict-Transport-Security", &findings)
+	for _, value := range values {
+		s.analyzeHSTS(value, &findings)
+	}
+	return findings
+}
+
+func (s *HeaderScanner) checkFrameOptions(t *Target) []Finding {
+	findings := []Finding{}
+	_, values := s.headerValues(t, "X-Frame-Options", &findings)
+	for _, value := range values {
+		if v := strings.ToUpper(value); v != "DENY" && v != "SAMEORIGIN" {
+			rule := rules.FindRule(rules.HeaderValueRules, "Invalid X-Frame-Options")
+			findings = append(findings, s.createFinding(rule, "misconfigured", rule.Risk))
+		}
+	}
+	return findings
+}
+
+func (s *HeaderScanner) checkContentTypeOptions(t *Target) []Finding {
+	findings := []Finding{}
+	_, values := s.headerValues(t, "X-Content-Type-Options", &findings)
+	for _, value := range values {
+		if strings.ToLower(value) != "nosniff" {
+			rule := rules.FindRule(rules.HeaderValueRules, "Invalid X-Content-Type-Options")
+			findings = append(findings, s.createFinding(rule, "misconfigured", rule.Risk))
+		}
+	}
+	return findings
+}
+
+func (s *HeaderScanner) checkReferrerPolicy(t *Target) []Finding {
+	findings := []Finding{}
+	rule, ok := t.Rule("Referrer-Policy")
+	if !ok {
+		return findings
+	}
+
+	// Repeated Referrer-Policy headers form one fallback list
+	values := t.Response.Header.Values(rule.Header)
+	// A <meta name="referrer"> policy replaces the header policy for the
+	// document, unless browsers ignore it because no token is recognised
+	metaPolicy := t.Meta.ReferrerPolicy
+	if len(values) > 0 && EffectiveReferrerPolicy(metaPolicy) == "" {
+		metaPolicy = ""
+	}
+	switch {
+	case metaPolicy != "":
+		meta := rules.FindRule(rules.MetaRules, "Referrer Policy Delivered via Meta")
+		finding := s.createFinding(meta, "meta", meta.Risk)
+		if len(values) > 0 {
+			finding.Description += " (overrides the Referrer-Policy header " + strings.Join(values, ",") + ")"
+		}
+		findings = append(findings, finding)
+		s.analyzeReferrerPolicy(metaPolicy, &findings)
+		markMeta(&findings, 1)
+	case len(values) > 0:
+		s.analyzeReferrerPolicy(strings.Join(values, ","), &findings)
+	default:
+		findings = append(findings, s.createFinding(rule, "missing", rule.Risk))
+	}
+	return findings
+}
+
+func (s *HeaderScanner) checkPermissionsPolicy(t *Target) []Finding {
+	findings := []Finding{}
+	_, values := s.headerValues(t, "Permissions-Policy", &findings)
+
+	// Repeated Permissions-Policy headers are combined into one dictionary
+	if len(values) > 0 {
+		s.analyzePermissionsPolicy(strings.Join(values, ","), &findings)
+	}
+	return findings
+}
+
+func (s *HeaderScanner) checkDisclosure(t *Target) []Finding {
+	findings := []Finding{}
+	for _, header := range []string{"Server", "X-Powered-By"} {
+		rule, values := s.headerValues(t, header, &findings)
+		for _, value := range values {
+			s.analyzeDisclosure(rule, value, &findings)
+		}
+	}
+	s.analyzeDisclosureHeaders(t.Response.Header, &findings)
+	return findings
+}
+
+func (s *HeaderScanner) checkCrossOrigin(t *Target) []Finding {
+	findings := []Finding{}
+	for _, header := range []string{"Cross-Origin-Opener-Policy", "Cross-Origin-Embedder-Policy", "Cross-Origin-Resource-Policy"} {
+		rule, values := s.headerValues(t, header, &findings)
+		for _, value := range values {
+			s.analyzeCrossOrigin(rule, value, &findings)
+		}
+	}
+	isolation := AssessCrossOriginIsolation(t.Response.Header)
+	t.Isolation = &isolation
+	return findings
+}
+
+func (s *HeaderScanner) checkCookies(t *Target) []Finding {
+	findings := []Finding{}
+
+	// Every Set-Cookie header is a separate cookie with its own attributes
+	if _, values := s.headerValues(t, "Set-Cookie", &findings); len(values) > 0 {
+		s.analyzeCookies(t.Response, &findings)
+	}
+	return findings
+}
+
+func (s *HeaderScanner) checkMeta(t *Target) []Finding {
+	findings := []Finding{}
+	if len(t.Meta.CSP) > 0 {
+		s.analyzeMetaIgnoredDirectives(t.Meta.CSP, &findings)
+	}
+	if t.Meta.Refresh != "" {
+		s.analyzeMetaRefresh(t.Response, t.Meta.Refresh, &findings)
+	}
+	return findings
+}
+
+func (s *HeaderScanner) checkSubresources(t *Target) []Finding {
+	findings := []Finding{}
+	s.analyzeSubresources(t.Response, t.Body, &findings)
+	return findings
+}
+
+func (s *HeaderScanner) checkMixedContent(t *Target) []Finding {
+	findings := []Finding{}
+	s.analyzeMixedContent(t.Response, t.Body, t.Meta.CSP, &findings)
+	return findings
+}
+
+func (s *HeaderScanner) checkCaching(t *Target) []Finding {
+	findings := []Finding{}
+	s.analyzeCaching(t.Response, &findings)
+	return findings
+}
+
+func (s *HeaderScanner) checkContentType(t *Target) []Finding {
+	findings := []Finding{}
+	s.analyzeContentType(t.Response, &findings)
+	return findings
+}
+
+func (s *HeaderScanner) checkDeprecated(t *Target) []Finding {
+	findings := []Finding{}
+	s.analyzeDeprecatedHeaders(t.Response.Header, &findings)
+	return findings
+}
+
+func (s *HeaderScanner) checkTechnologies(t *Target) []Finding {
+	findings := []Finding{}
+	t.Technologies = s.Fingerprint(t.Response.Header)
+	s.analyzeTechnologies(t.Technologies, &findings)
+	return findings
+}
+
+func (s *HeaderScanner) checkCustomRules(t *Target) []Finding {
+	findings := []Finding{}
+	s.analyzeCustomRules(t.Response.Header, &findings)
+	return findings
+}
+
+// checkBearerToken inspects the bearer token sent with the request and tokens
+// issued in token response headers or a JSON body, like JWT cookies.
+func (s *HeaderScanner) checkBearerToken(t *Target) []Finding {
+	findings := []Finding{}
+	now := time.Now()
+	if t.Request != nil {
+		for _, value := range t.Request.Header.Values("Authorization") {
+			s.analyzeJWT(value, "Authorization", "", now, &findings)
+		}
+	}
+	for _, header := range tokenResponseHeaders {
+		for _, value := range t.Response.Header.Values(header) {
+			s.analyzeJWT(value, header, "", now, &findings)
+		}
+	}
+
+	mediaType, _, _ := mime.ParseMediaType(t.Response.Header.Get("Content-Type"))
+	if contentFamily(mediaType) == "json" {
+		s.analyzeJSONTokens(peekBody(t.Response, maxBodyLen), now, &findings)
+	}
+	return findings
+}
+
+// checkHSTSPreload traces plain HTTP on the same host to judge preload
+// eligibility. It sends a request, so it only runs with a client on the
+// scanner and while the profile keeps the preload rule enabled.
+func (s *HeaderScanner) checkHSTSPreload(t *Target) []Finding {
+	findings := []Finding{}
+	rule := rules.FindRule(rules.HSTSRules, "HSTS Preload Ineligible")
+	req := t.Response.Request
+	if s.Client == nil || !s.Profile.Enabled(rule.ID) || req == nil || req.URL == nil || req.URL.Scheme != "https" {
+		return findings
+	}
+	httpRedirects, _ := AnalyzeRedirects(s.Client, "http://"+req.URL.Hostname()+"/")
+	preload := s.hstsPreload(t.Response, httpRedirects, &findings)
+	t.HSTSPreload = &preload
+	return findings
+}
+
+// checkCORSProbe re-requests the target with crafted origins when ProbeCORS
+// is set on a scanner with a client and the profile keeps a CORS rule enabled.
+func (s *HeaderScanner) checkCORSProbe(t *Target) []Finding {
+	req := t.Response.Request
+	if s.Client == nil || !s.ProbeCORS || req == nil || req.URL == nil {
+		return []Finding{}
+	}
+	for _, rule := range rules.CORSRules {
+		if s.Profile.Enabled(rule.ID) {
+			return s.probeCORS(s.Client, req.URL.String())
+		}
+	}
+	return []Finding{}
+}
@@ -0,0 +1,83 @@
+package scanner
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/ismailtsdln/HeaderSentinel/internal/rules"
+)
+
+func TestCORSProbeCheck(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if origin := r.Header.Get("Origin"); origin != "" {
+			requests.Add(1)
+			w.Header().Set("Access-Control-Allow-Origin", origin)
+			w.Header().Set("Access-Control-Allow-Credentials", "true")
+		}
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name      string
+		client    bool
+		probe     bool
+		disabled  []string
+		wantProbe bool
+	}{
+		{"passive by default", false, false, nil, false},
+		{"client without ProbeCORS", true, false, nil, false},
+		{"ProbeCORS without client", false, true, nil, false},
+		{"client and ProbeCORS", true, true, nil, true},
+		{"CORS rules disabled by profile", true, true, []string{"HS-CORS-*"}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			requests.Store(0)
+			s := NewHeaderScanner()
+			s.UseProfile(rules.Profile{Disabled: tt.disabled})
+			if tt.client {
+				s.Client = server.Client()
+			}
+			s.ProbeCORS = tt.probe
+
+			resp, err := server.Client().Get(server.URL)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer resp.Body.Close()
+			reported := false
+			for _, f := range s.ScanTarget(NewTarget(resp, RedirectResult{})) {
+				reported = reported || strings.HasPrefix(f.RuleID, "HS-CORS-")
+			}
+			if probed := requests.Load() > 0; probed != tt.wantProbe || reported != tt.wantProbe {
+				t.Errorf("probed = %v, reported = %v, want %v", probed, reported, tt.wantProbe)
+			}
+		})
+	}
+}
+
+func TestScanTargetRecordsResults(t *testing.T) {
+	rec := httptest.NewRecorder()
+	rec.Header().Set("Server", "Apache/2.4.62")
+	rec.Header().Set("Cross-Origin-Opener-Policy", "same-origin")
+	rec.Header().Set("Cross-Origin-Embedder-Policy", "require-corp")
+	resp := rec.Result()
+	resp.Request = httptest.NewRequest(http.MethodGet, "https://example.com/", nil)
+
+	target := NewTarget(resp, RedirectResult{})
+	NewHeaderScanner().ScanTarget(target)
+	if len(target.Technologies) == 0 || target.Technologies[0].Name != "Apache HTTP Server" {
+		t.Errorf("Technologies = %+v, want Apache HTTP Server", target.Technologies)
+	}
+	if target.Isolation == nil || !target.Isolation.Isolated {
+		t.Errorf("Isolation = %+v, want isolated", target.Isolation)
+	}
+	// Without a client the preload probe sends nothing and records no result
+	if target.HSTSPreload != nil {
+		t.Errorf("HSTSPreload = %+v, want nil", target.HSTSPreload)
+	}
+}
@@ -0,0 +1,121 @@
+package scanner
+
+import (
+	"bytes"
+	"io"
+	"mime"
+	"net/http"
+	"strings"
+
+	"github.com/ismailtsdln/HeaderSentinel/internal/rules"
+)
+
+// charsetPrescanLen is how far browsers look for a <meta> charset declaration.
+const charsetPrescanLen = 1024
+
+// peekBody returns up to n bytes of the response body and puts them back, so
+// later readers still see the whole body.
+func peekBody(resp *http.Response, n int) []byte {
+	if resp.Body == nil || resp.Body == http.NoBody {
+		return nil
+	}
+	buf := make([]byte, n)
+	read, _ := io.ReadFull(resp.Body, buf)
+	buf = buf[:read]
+	resp.Body = struct {
+		io.Reader
+		io.Closer
+	}{io.MultiReader(bytes.NewReader(buf), resp.Body), resp.Body}
+	return buf
+}
+
+// contentFamily reduces a media type to the family browsers care about when
+// sniffing: html, json, script, text, image or other.
+func contentFamily(mediaType string) string {
+	switch {
+	case mediaType == "text/html" || mediaType == "application/xhtml+xml":
+		return "html"
+	case mediaType == "application/json" || strings.HasSuffix(mediaType, "+json"):
+		return "json"
+	case strings.Contains(mediaType, "javascript") || mediaType == "text/ecmascript":
+		return "script"
+	case strings.HasPrefix(mediaType, "image/"):
+		return "image"
+	case strings.HasPrefix(mediaType, "text/"):
+		return "text"
+	}
+	return "other"
+}
+
+// sniffFamily guesses the content family of a body prefix.
+func sniffFamily(body []byte) string {
+	trimmed := bytes.TrimSpace(body)
+	if len(trimmed) > 0 && (trimmed[0] == '{' || trimmed[0] == '[') {
+		return "json"
+	}
+	mediaType, _, _ := mime.ParseMediaType(http.DetectContentType(body))
+	return contentFamily(mediaType)
+}
+
+func (s *HeaderScanner) analyzeContentType(resp *http.Response, findings *[]Finding) {
+	report := func(checkName, detail string) {
+		rule := rules.FindRule(rules.ContentTypeRules, checkName)
+		finding := s.createFinding(rule, "misconfigured", rule.Risk)
+		if detail != "" {
+			finding.Description += " (" + detail + ")"
+		}
+		*findings = append(*findings, finding)
+	}
+
+	body := peekBody(resp, charsetPrescanLen)
+	if len(body) == 0 {
+		return
+	}
+
+	value := resp.Header.Get("Content-Type")
+	if value == "" {
+		report("Missing Content-Type", "")
+		return
+	}
+	mediaType, params, err := mime.ParseMediaType(value)
+	if err != nil {
+		report("Missing Content-Type", "Content-Type "+value+" cannot be parsed")
+		return
+	}
+
+	declared, sniffed := contentFamily(mediaType), sniffFamily(body)
+	if declared == "html" && params["charset"] == "" && metaCharset(body) == "" {
+		report("HTML Without Charset", "Content-Type: "+value)
+	}
+	if declared == "html" && sniffed == "json" {
+		report("JSON Served as HTML", "Content-Type: "+value)
+		return
+	}
+
+	nosniff := strings.EqualFold(strings.TrimSpace(resp.Header.Get("X-Content-Type-Options")), "nosniff")
+	// Plain text and unknown binary bodies cannot be told apart from most types by sniffing
+	if nosniff && sniffed != "text" && sniffed != "other" && sniffed != declared {
+		report("nosniff With Mismatched Content-Type", "declared "+mediaType+", body looks like "+sniffed)
+	}
+}
+
+// metaCharset returns the charset an HTML document declares with
+// <meta charset> or <meta http-equiv="Content-Type">, as browsers honour it
+// when the header has none.
+func metaCharset(body []byte) string {
+	if len(body) > charsetPrescanLen {
+		body = body[:charsetPrescanLen]
+	}
+	for _, tag := range metaTagPattern.FindAll(body, -1) {
+		attrs := htmlAttributes(string(tag))
+		if charset := strings.TrimSpace(attrs["charset"]); charset != "" {
+			return charset
+		}
+		if strings.EqualFold(attrs["http-equiv"], "content-type") {
+			if _, params, err := mime.ParseMediaType(attrs["content"]); err == nil && params["charset"] != "" {
+				return params["charset"]
+			}
+		}
+	}
+	return ""
+}
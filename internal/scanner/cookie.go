@@ -0,0 +1,201 @@
+package scanner
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ismailtsdln/HeaderSentinel/internal/rules"
+)
+
+// sessionCookieMaxAge is the lifetime above which a persistent session cookie is reported.
+const sessionCookieMaxAge = 30 * 24 * time.Hour
+
+// analyzeCookies parses every Set-Cookie header of a response and checks the
+// attributes of each cookie separately.
+func (s *HeaderScanner) analyzeCookies(resp *http.Response, findings *[]Finding) {
+	now := time.Now()
+	if date, err := http.ParseTime(resp.Header.Get("Date")); err == nil {
+		now = date
+	}
+
+	for _, value := range resp.Header.Values("Set-Cookie") {
+		cookie, ok := parseSetCookie(value)
+		if !ok {
+			continue
+		}
+		s.analyzeCookie(cookie, now, findings)
+	}
+}
+
+// parseSetCookie parses a Set-Cookie value. Values that browsers accept but
+// http.ParseSetCookie rejects, such as raw JSON or backslashes, are split
+// leniently on ";" so their attributes are still checked.
+func parseSetCookie(value string) (*http.Cookie, bool) {
+	if cookie, err := http.ParseSetCookie(value); err == nil {
+		return cookie, true
+	}
+
+	parts := strings.Split(value, ";")
+	name, val, ok := strings.Cut(parts[0], "=")
+	name = strings.TrimSpace(name)
+	if !ok || name == "" {
+		return nil, false
+	}
+	cookie := &http.Cookie{Name: name, Value: strings.TrimSpace(val), Raw: value}
+	for _, part := range parts[1:] {
+		attr, val, _ := strings.Cut(strings.TrimSpace(part), "=")
+		val = strings.TrimSpace(val)
+		switch strings.ToLower(strings.TrimSpace(attr)) {
+		case "secure":
+			cookie.Secure = true
+		case "httponly":
+			cookie.HttpOnly = true
+		case "domain":
+			cookie.Domain = val
+		case "path":
+			cookie.Path = val
+		case "samesite":
+			switch strings.ToLower(val) {
+			case "lax":
+				cookie.SameSite = http.SameSiteLaxMode
+			case "strict":
+				cookie.SameSite = http.SameSiteStrictMode
+			case "none":
+				cookie.SameSite = http.SameSiteNoneMode
+			default:
+				cookie.SameSite = http.SameSiteDefaultMode
+			}
+		case "max-age":
+			if secs, err := strconv.Atoi(val); err == nil {
+				if secs <= 0 {
+					secs = -1 // expire immediately, as http.Cookie encodes it
+				}
+				cookie.MaxAge = secs
+			}
+		case "expires":
+			if t, err := http.ParseTime(val); err == nil {
+				cookie.Expires = t
+			}
+		}
+	}
+	return cookie, true
+}
+
+func (s *HeaderScanner) analyzeCookie(cookie *http.Cookie, now time.Time, findings *[]Finding) {
+	class, reason := ClassifyCookie(cookie.Name, cookie.Value)
+
+	report := func(checkName, detail string) {
+		rule := rules.FindRule(rules.CookieRules, checkName)
+		risk := adjustCookieRisk(checkName, rule.Risk, class)
+		status := "misconfigured"
+		if risk == rules.RiskInfo {
+			status = "present"
+		}
+		finding := s.createFinding(rule, status, risk)
+		finding.Cookie = cookie.Name
+		if class != CookieUnknown {
+			finding.Classification = string(class) + ": " + reason
+		}
+		finding.Description += " (cookie " + cookie.Name
+		if detail != "" {
+			finding.Description += ": " + detail
+		}
+		finding.Description += ")"
+		*findings = append(*findings, finding)
+	}
+
+	if !cookie.HttpOnly {
+		report("Insecure Cookie (Missing HttpOnly)", "")
+	}
+	if !cookie.Secure {
+		report("Insecure Cookie (Missing Secure)", "")
+	}
+	switch cookie.SameSite {
+	case 0:
+		report("Insecure Cookie (Missing SameSite)", "")
+	case http.SameSiteNoneMode:
+		if !cookie.Secure {
+			report("Cookie SameSite=None Without Secure", "")
+		}
+	}
+
+	switch {
+	case strings.HasPrefix(cookie.Name, "__Host-"):
+		var problems []string
+		if !cookie.Secure {
+			problems = append(problems, "missing Secure")
+		}
+		if cookie.Path != "/" {
+			problems = append(problems, "Path is not /")
+		}
+		if cookie.Domain != "" {
+			problems = append(problems, "Domain is set")
+		}
+		if len(problems) > 0 {
+			report("Cookie Prefix Violation", strings.Join(problems, ", "))
+		}
+	case strings.HasPrefix(cookie.Name, "__Secure-"):
+		if !cookie.Secure {
+			report("Cookie Prefix Violation", "missing Secure")
+		}
+	}
+
+	// Even a Domain equal to the host shares the cookie with all of its subdomains
+	if domain := strings.ToLower(strings.TrimPrefix(cookie.Domain, ".")); domain != "" {
+		report("Cookie Overly Broad Domain", fmt.Sprintf("Domain=%s covers every subdomain of %s", cookie.Domain, domain))
+	}
+	if cookie.Path == "" {
+		report("Cookie Missing Path", "")
+	}
+
+	if lifetime := cookieLifetime(cookie, now); lifetime > sessionCookieMaxAge && class == CookieSession {
+		report("Long-Lived Session Cookie", fmt.Sprintf("expires in %d days", int(lifetime.Hours()/24)))
+	}
+
+	s.analyzeJWT(cookie.Value, "Set-Cookie", cookie.Name, now, findings)
+}
+
+// cookieLifetime returns how long a cookie persists, or zero for a session
+// cookie. Max-Age takes precedence over Expires.
+func cookieLifetime(cookie *http.Cookie, now time.Time) time.Duration {
+	switch {
+	case cookie.MaxAge > 0:
+		return time.Duration(cookie.MaxAge) * time.Second
+	case cookie.MaxAge < 0:
+		return 0
+	case !cookie.Expires.IsZero():
+		return cookie.Expires.Sub(now)
+	}
+	return 0
+}
+
+// adjustCookieRisk escalates missing-flag findings on likely session cookies
+// and de-escalates them on preference cookies and on the missing HttpOnly of
+// anti-CSRF tokens.
+func adjustCookieRisk(checkName string, risk rules.RiskLevel, class CookieClass) rules.RiskLevel {
+	switch checkName {
+	case "Insecure Cookie (Missing HttpOnly)", "Insecure Cookie (Missing Secure)":
+		switch class {
+		case CookieCSRF:
+			// Double-submit CSRF tokens are read by scripts, but must not leak over HTTP
+			if checkName == "Insecure Cookie (Missing HttpOnly)" {
+				return rules.RiskInfo
+			}
+		case CookieSession:
+			return rules.RiskHigh
+		case CookiePreference:
+			return rules.RiskLow
+		}
+	case "Insecure Cookie (Missing SameSite)":
+		switch class {
+		case CookieSession:
+			return rules.RiskMedium
+		case CookiePreference:
+			return rules.RiskInfo
+		}
+	}
+	return risk
+}
@@ -0,0 +1,131 @@
+package scanner
+
+import (
+	"math"
+	"strings"
+)
+
+// CookieClass is the heuristic sensitivity class of a cookie.
+type CookieClass string
+
+const (
+	CookieSession    CookieClass = "session"
+	CookieCSRF       CookieClass = "csrf"
+	CookiePreference CookieClass = "preference"
+	CookieUnknown    CookieClass = "unknown"
+)
+
+// sessionCookieNames lists cookie names used by common frameworks for session
+// identifiers, in lower case.
+var sessionCookieNames = map[string]bool{
+	"phpsessid":         true,
+	"jsessionid":        true,
+	"asp.net_sessionid": true,
+	".aspxauth":         true,
+	"connect.sid":       true,
+	"laravel_session":   true,
+	"_session_id":       true,
+	"sessionid":         true,
+	"session":           true,
+	"sid":               true,
+	"cfid":              true,
+	"cftoken":           true,
+	"access_token":      true,
+	"refresh_token":     true,
+	"remember_token":    true,
+}
+
+// sessionCookieHints are name segments that suggest a session or
+// authentication cookie. Hints of four or more letters also match at the start
+// or end of a segment, so "sessionid" and "authtoken" match but "sidebar" does not.
+var sessionCookieHints = []string{"sess", "session", "sid", "auth", "token", "login", "jwt"}
+
+// csrfCookieHints are name segments of anti-CSRF token cookies, which
+// double-submit schemes deliberately leave readable by scripts.
+var csrfCookieHints = []string{"csrf", "xsrf", "csrftoken", "xsrftoken"}
+
+// preferenceCookieHints are substrings of names used for UI preferences and
+// consent state, which carry nothing an attacker could reuse.
+var preferenceCookieHints = []string{"theme", "lang", "locale", "currency", "timezone", "consent", "dark_mode", "font_size"}
+
+// ClassifyCookie guesses whether a cookie carries a session or authentication
+// token, or only a preference, and returns the reason for the guess.
+func ClassifyCookie(name, value string) (CookieClass, string) {
+	lower := strings.ToLower(name)
+
+	segments := cookieNameSegments(lower)
+
+	switch {
+	case sessionCookieNames[lower] || strings.HasPrefix(lower, "aspsessionid"):
+		return CookieSession, "well-known session cookie name"
+	case matchesSegment(segments, csrfCookieHints):
+		return CookieCSRF, "anti-CSRF token name"
+	case looksLikeJWT(value):
+		return CookieSession, "JWT-shaped value"
+	}
+	for _, hint := range sessionCookieHints {
+		if matchesSegment(segments, []string{hint}) {
+			return CookieSession, "name contains \"" + hint + "\""
+		}
+	}
+	if isHighEntropy(value) {
+		return CookieSession, "high-entropy value"
+	}
+	for _, hint := range preferenceCookieHints {
+		if strings.Contains(lower, hint) {
+			return CookiePreference, "preference cookie name"
+		}
+	}
+	return CookieUnknown, ""
+}
+
+// cookieNameSegments splits a lower-case cookie name on "_", "-" and ".".
+func cookieNameSegments(name string) []string {
+	return strings.FieldsFunc(name, func(r rune) bool { return r == '_' || r == '-' || r == '.' })
+}
+
+// matchesSegment reports whether a name segment equals one of the hints, or
+// starts or ends with a hint of four or more letters.
+func matchesSegment(segments, hints []string) bool {
+	for _, seg := range segments {
+		for _, hint := range hints {
+			if seg == hint || len(hint) >= 4 && (strings.HasPrefix(seg, hint) || strings.HasSuffix(seg, hint)) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// looksLikeJWT reports whether a value has the three base64url segments of a
+// JSON Web Token with a JSON header.
+func looksLikeJWT(value string) bool {
+	parts := strings.Split(value, ".")
+	if len(parts) != 3 || !strings.HasPrefix(parts[0], "eyJ") {
+		return false
+	}
+	for _, part := range parts[:2] {
+		if part == "" || strings.Trim(part, "ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789-_=") != "" {
+			return false
+		}
+	}
+	return true
+}
+
+// isHighEntropy reports whether a value looks like a random identifier: at
+// least 16 characters and more than 3.5 bits of Shannon entropy per character.
+func isHighEntropy(value string) bool {
+	if len(value) < 16 {
+		return false
+	}
+	counts := map[rune]int{}
+	for _, r := range value {
+		counts[r]++
+	}
+	entropy := 0.0
+	for _, n := range counts {
+		p := float64(n) / float64(len(value))
+		entropy -= p * math.Log2(p)
+	}
+	return entropy > 3.5
+}
@@ -0,0 +1,103 @@
+package scanner
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func TestParseSetCookie(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+		want  *http.Cookie
+	}{
+		{
+			name:  "raw JSON value",
+			value: `prefs={"a":1}; Path=/; Secure; HttpOnly; SameSite=Lax; Max-Age=0`,
+			want:  &http.Cookie{Name: "prefs", Value: `{"a":1}`, Path: "/", Secure: true, HttpOnly: true, SameSite: http.SameSiteLaxMode, MaxAge: -1},
+		},
+		{
+			name:  "backslash in value",
+			value: `id=a\b; domain=example.com; samesite=none; max-age=60`,
+			want:  &http.Cookie{Name: "id", Value: `a\b`, Domain: "example.com", SameSite: http.SameSiteNoneMode, MaxAge: 60},
+		},
+		{
+			name:  "no name",
+			value: `={"a":1}`,
+		},
+		{
+			name:  "no value",
+			value: `"broken`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := parseSetCookie(tt.value)
+			if tt.want == nil {
+				if ok {
+					t.Errorf("parseSetCookie(%q) = %+v, want failure", tt.value, got)
+				}
+				return
+			}
+			if !ok {
+				t.Fatalf("parseSetCookie(%q) failed", tt.value)
+			}
+			got.Raw = ""
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseSetCookie(%q) = %+v, want %+v", tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAnalyzeCookies(t *testing.T) {
+	tests := []struct {
+		name   string
+		cookie string
+		want   []string // rule IDs, sorted
+	}{
+		{
+			name:   "hardened cookie",
+			cookie: "theme=dark; Path=/; Secure; HttpOnly; SameSite=Lax",
+			want:   []string{},
+		},
+		{
+			name:   "value rejected by net/http is still audited",
+			cookie: `prefs={"a":1}; Path=/`,
+			want:   []string{"HS-COOK-002", "HS-COOK-003", "HS-COOK-004"},
+		},
+		{
+			name:   "Domain equal to the host",
+			cookie: "theme=dark; Domain=example.com; Path=/; Secure; HttpOnly; SameSite=Lax",
+			want:   []string{"HS-COOK-007"},
+		},
+		{
+			name:   "Domain of a parent domain",
+			cookie: "theme=dark; Domain=.example.com; Path=/; Secure; HttpOnly; SameSite=Lax",
+			want:   []string{"HS-COOK-007"},
+		},
+	}
+	s := NewHeaderScanner()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			rec.Header().Add("Set-Cookie", tt.cookie)
+			resp := rec.Result()
+			resp.Request = httptest.NewRequest(http.MethodGet, "https://example.com/", nil)
+
+			findings := []Finding{}
+			s.analyzeCookies(resp, &findings)
+			got := []string{}
+			for _, f := range findings {
+				got = append(got, f.RuleID)
+			}
+			sort.Strings(got)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("analyzeCookies(%q) = %v, want %v", tt.cookie, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,143 @@
+package scanner
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/ismailtsdln/HeaderSentinel/internal/rules"
+)
+
+// corsAttackerDomain is the domain used for crafted Origin headers. It is
+// reserved by RFC 2606 and never resolves.
+const corsAttackerDomain = "attacker.example"
+
+// corsProbe is a crafted Origin sent to the target and the check reported
+// when the server trusts it.
+type corsProbe struct {
+	Origin    string
+	CheckName string
+}
+
+// corsProbes builds the crafted origins for a target. The arbitrary origin
+// comes first, so the probes it already implies can be skipped.
+func corsProbes(u *url.URL) []corsProbe {
+	host := u.Hostname()
+	label := strings.Split(host, ".")[0]
+	return []corsProbe{
+		{"https://" + corsAttackerDomain, "CORS Reflected Origin"},
+		{"null", "CORS Null Origin Trusted"},
+		{"https://" + host + "." + corsAttackerDomain, "CORS Lookalike Origin Trusted"},
+		{"https://" + label + "-" + corsAttackerDomain, "CORS Lookalike Origin Trusted"},
+		{"https://" + corsAttackerDomain + "." + strings.TrimPrefix(host, "www."), "CORS Lookalike Origin Trusted"},
+		{"https://attacker" + host, "CORS Lookalike Origin Trusted"},
+		{"http://" + u.Host, "CORS Insecure Origin Trusted"},
+	}
+}
+
+// probeCORS actively re-requests a target with crafted Origin headers and an
+// OPTIONS preflight, and reports the origins the server trusts.
+func (s *HeaderScanner) probeCORS(client *http.Client, target string) []Finding {
+	findings := []Finding{}
+	u, err := url.Parse(target)
+	if err != nil {
+		return findings
+	}
+
+	report := func(checkName string, credentials bool, detail string) {
+		rule := rules.FindRule(rules.CORSRules, checkName)
+		risk := rule.Risk
+		if !credentials && checkName != "CORS Wildcard With Credentials" && checkName != "CORS Permissive Preflight" {
+			risk = lowerRisk(risk)
+			detail += ", without credentials"
+		}
+		finding := s.createFinding(rule, "misconfigured", risk)
+		finding.Description += " (" + detail + ")"
+		findings = append(findings, finding)
+	}
+
+	seen := map[string]bool{}
+	wildcardReported := false
+	for _, probe := range corsProbes(u) {
+		// Once any origin is reflected, lookalike and http:// origins add nothing
+		if seen["CORS Reflected Origin"] && probe.CheckName != "CORS Null Origin Trusted" {
+			continue
+		}
+		resp, err := corsRequest(client, http.MethodGet, target, probe.Origin, nil)
+		if err != nil {
+			continue
+		}
+		allowOrigin := resp.Header.Get("Access-Control-Allow-Origin")
+		credentials := strings.EqualFold(resp.Header.Get("Access-Control-Allow-Credentials"), "true")
+
+		switch {
+		case allowOrigin == "*" && credentials && !wildcardReported:
+			wildcardReported = true
+			report("CORS Wildcard With Credentials", true, "Access-Control-Allow-Origin: *")
+		case allowOrigin == probe.Origin && !seen[probe.CheckName]:
+			seen[probe.CheckName] = true
+			report(probe.CheckName, credentials, "Origin "+probe.Origin+" is allowed")
+		}
+	}
+
+	// The preflight uses the attacker origin, so it only matters if that origin is accepted
+	origin := "https://" + corsAttackerDomain
+	resp, err := corsRequest(client, http.MethodOptions, target, origin, map[string]string{
+		"Access-Control-Request-Method":  "DELETE",
+		"Access-Control-Request-Headers": "x-headersentinel-probe",
+	})
+	if err != nil {
+		return findings
+	}
+	allowOrigin := resp.Header.Get("Access-Control-Allow-Origin")
+	if allowOrigin != "*" && allowOrigin != origin {
+		return findings
+	}
+	problems := []string{}
+	methods := strings.ToUpper(resp.Header.Get("Access-Control-Allow-Methods"))
+	if strings.Contains(methods, "*") || strings.Contains(methods, "DELETE") {
+		problems = append(problems, "Access-Control-Allow-Methods: "+resp.Header.Get("Access-Control-Allow-Methods"))
+	}
+	headers := strings.ToLower(resp.Header.Get("Access-Control-Allow-Headers"))
+	if strings.Contains(headers, "*") || strings.Contains(headers, "x-headersentinel-probe") {
+		problems = append(problems, "Access-Control-Allow-Headers: "+resp.Header.Get("Access-Control-Allow-Headers"))
+	}
+	if len(problems) > 0 {
+		report("CORS Permissive Preflight", true, fmt.Sprintf("Origin %s: %s", origin, strings.Join(problems, "; ")))
+	}
+	return findings
+}
+
+// corsRequest sends a request with the given Origin and returns the response
+// with its body already closed.
+func corsRequest(client *http.Client, method, target, origin string, extra map[string]string) (*http.Response, error) {
+	req, err := http.NewRequest(method, target, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", "HeaderSentinel/1.0.0")
+	req.Header.Set("Origin", origin)
+	for name, value := range extra {
+		req.Header.Set(name, value)
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	resp.Body.Close()
+	return resp, nil
+}
+
+// lowerRisk returns the next lower risk level.
+func lowerRisk(risk rules.RiskLevel) rules.RiskLevel {
+	switch risk {
+	case rules.RiskCritical:
+		return rules.RiskHigh
+	case rules.RiskHigh:
+		return rules.RiskMedium
+	case rules.RiskMedium:
+		return rules.RiskLow
+	}
+	return rules.RiskInfo
+}
@@ -0,0 +1,307 @@
+package scanner
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ismailtsdln/HeaderSentinel/internal/rules"
+)
+
+// CSPPolicy represents a single parsed Content-Security-Policy.
+type CSPPolicy struct {
+	Names      []string // directive names in the order they appear
+	Directives map[string][]string
+}
+
+// cspFallbacks lists the directives consulted, in order, when a fetch directive is absent.
+var cspFallbacks = map[string][]string{
+	"script-src":      {"script-src", "default-src"},
+	"script-src-elem": {"script-src-elem", "script-src", "default-src"},
+	"script-src-attr": {"script-src-attr", "script-src", "default-src"},
+	"style-src":       {"style-src", "default-src"},
+	"img-src":         {"img-src", "default-src"},
+	"font-src":        {"font-src", "default-src"},
+	"connect-src":     {"connect-src", "default-src"},
+	"media-src":       {"media-src", "default-src"},
+	"object-src":      {"object-src", "default-src"},
+	"frame-src":       {"frame-src", "child-src", "default-src"},
+	"worker-src":      {"worker-src", "child-src", "script-src", "default-src"},
+	"manifest-src":    {"manifest-src", "default-src"},
+}
+
+// ParseCSP parses a Content-Security-Policy header value. Multiple policies
+// (separated by commas, or sent as repeated headers) are returned separately.
+func ParseCSP(value string) []CSPPolicy {
+	policies := []CSPPolicy{}
+
+	for _, raw := range strings.Split(value, ",") {
+		policy := CSPPolicy{Directives: map[string][]string{}}
+
+		for _, directive := range strings.Split(raw, ";") {
+			fields := strings.Fields(directive)
+			if len(fields) == 0 {
+				continue
+			}
+			name := strings.ToLower(fields[0])
+			// Browsers ignore repeated directives, the first one wins.
+			if _, ok := policy.Directives[name]; ok {
+				continue
+			}
+			policy.Names = append(policy.Names, name)
+			policy.Directives[name] = fields[1:]
+		}
+
+		if len(policy.Directives) > 0 {
+			policies = append(policies, policy)
+		}
+	}
+
+	return policies
+}
+
+// Has reports whether the policy explicitly defines the directive.
+func (p CSPPolicy) Has(directive string) bool {
+	_, ok := p.Directives[directive]
+	return ok
+}
+
+// EffectiveSources returns the source list that applies to a directive after
+// resolving fallbacks, together with the name of the directive that supplied it.
+func (p CSPPolicy) EffectiveSources(directive string) ([]string, string) {
+	chain, ok := cspFallbacks[directive]
+	if !ok {
+		chain = []string{directive}
+	}
+	for _, name := range chain {
+		if sources, ok := p.Directives[name]; ok {
+			return sources, name
+		}
+	}
+	return nil, ""
+}
+
+// analyzeCSP evaluates the directives of a policy. Weaknesses in a report-only
+// policy are not enforced, so they are reported for information only.
+func (s *HeaderScanner) analyzeCSP(value string, reportOnly bool, findings *[]Finding) {
+	policies := ParseCSP(value)
+	if len(policies) == 0 {
+		return
+	}
+
+	report := func(checkName, detail string) {
+		rule := rules.FindRule(rules.CSPRules, checkName)
+		finding := s.createFinding(rule, "misconfigured", rule.Risk)
+		if detail != "" {
+			finding.Description += " (" + detail + ")"
+		}
+		if reportOnly {
+			finding.Header = "Content-Security-Policy-Report-Only"
+			finding.Status = "report-only"
+			finding.Risk = rules.RiskInfo
+		}
+		*findings = append(*findings, finding)
+	}
+
+	hasObjectSrc, hasBaseURI := false, false
+	for _, policy := range policies {
+		if _, from := policy.EffectiveSources("object-src"); from != "" {
+			hasObjectSrc = true
+		}
+		if policy.Has("base-uri") {
+			hasBaseURI = true
+		}
+
+		scriptSources, scriptFrom := policy.EffectiveSources("script-src")
+		// With 'strict-dynamic' browsers ignore host and scheme sources in script-src
+		strictDynamic := hasNonceOrHash(scriptSources) && containsSource(scriptSources, "'strict-dynamic'")
+
+		// Several directives can resolve to the same default-src, report each source once
+		seen := map[string]bool{}
+		for _, directive := range []string{"script-src", "object-src", "style-src", "frame-src", "base-uri", "form-action", "frame-ancestors"} {
+			if strictDynamic && directive == "script-src" {
+				continue
+			}
+			sources, from := policy.EffectiveSources(directive)
+			for _, src := range sources {
+				if isWildcardSource(src) && !seen[from+" "+src] {
+					seen[from+" "+src] = true
+					report("CSP Wildcard Source", fmt.Sprintf("%s allows %s", from, src))
+				}
+			}
+		}
+
+		for _, src := range scriptSources {
+			if strictDynamic {
+				break
+			}
+			if bypass, ok := findCSPBypass(src); ok {
+				report("CSP Bypassable Host", fmt.Sprintf("%s allows %s, bypass gadget %s%s: %s", scriptFrom, src, bypass.Host, bypass.Path, bypass.Description))
+			}
+		}
+
+		for _, src := range scriptSources {
+			switch strings.ToLower(src) {
+			case "data:", "blob:":
+				if !strictDynamic {
+					report("CSP Unsafe Scheme in script-src", fmt.Sprintf("%s allows %s", scriptFrom, src))
+				}
+			case "'unsafe-inline'":
+				// Browsers ignore 'unsafe-inline' when a nonce or hash is present
+				if !hasNonceOrHash(scriptSources) {
+					report("CSP Unsafe Inline", scriptFrom)
+				}
+			case "'unsafe-eval'":
+				report("CSP Unsafe Eval", scriptFrom)
+			}
+		}
+
+		for _, name := range policy.Names {
+			// A default-src that script-src falls back to still governs the other directives
+			if strictDynamic && name == "script-src" {
+				continue
+			}
+			for _, src := range policy.Directives[name] {
+				lower := strings.ToLower(src)
+				if lower == "http:" || strings.HasPrefix(lower, "http://") {
+					report("CSP Insecure Scheme Source", fmt.Sprintf("%s allows %s", name, src))
+				}
+			}
+		}
+	}
+
+	if !hasObjectSrc {
+		report("CSP Missing object-src", "")
+	}
+	if !hasBaseURI {
+		report("CSP Missing base-uri", "")
+	}
+
+	if reportOnly {
+		return
+	}
+	for _, policy := range policies {
+		if isStrictCSP(policy) {
+			rule := rules.FindRule(rules.CSPRules, "CSP Strict Policy")
+			*findings = append(*findings, s.createFinding(rule, "strict", rules.RiskInfo))
+			break
+		}
+	}
+}
+
+// isStrictCSP reports whether a policy restricts scripts with nonces or hashes
+// instead of host allowlists, and locks down plugins and <base>.
+func isStrictCSP(policy CSPPolicy) bool {
+	scriptSources, _ := policy.EffectiveSources("script-src")
+	if !hasNonceOrHash(scriptSources) {
+		return false
+	}
+	if !containsSource(scriptSources, "'strict-dynamic'") {
+		// Without 'strict-dynamic' any host or scheme allowlist is still honoured
+		for _, src := range scriptSources {
+			if !strings.HasPrefix(src, "'") {
+				return false
+			}
+		}
+	}
+	if containsSource(scriptSources, "'unsafe-eval'") {
+		return false
+	}
+
+	objectSources, _ := policy.EffectiveSources("object-src")
+	if len(objectSources) != 1 || strings.ToLower(objectSources[0]) != "'none'" {
+		return false
+	}
+	return policy.Has("base-uri") && !containsSource(policy.Directives["base-uri"], "*")
+}
+
+// hasNonceOrHash reports whether a source list contains a nonce or hash source.
+func hasNonceOrHash(sources []string) bool {
+	for _, src := range sources {
+		lower := strings.ToLower(src)
+		if strings.HasPrefix(lower, "'nonce-") || strings.HasPrefix(lower, "'sha256-") ||
+			strings.HasPrefix(lower, "'sha384-") || strings.HasPrefix(lower, "'sha512-") {
+			return true
+		}
+	}
+	return false
+}
+
+func containsSource(sources []string, want string) bool {
+	for _, src := range sources {
+		if strings.EqualFold(src, want) {
+			return true
+		}
+	}
+	return false
+}
+
+// findCSPBypass returns the known bypass gadget allowed by a host source expression.
+func findCSPBypass(src string) (rules.CSPBypass, bool) {
+	lower := strings.ToLower(src)
+	if strings.HasPrefix(lower, "'") || isWildcardSource(lower) || strings.HasSuffix(lower, ":") {
+		return rules.CSPBypass{}, false
+	}
+	if i := strings.Index(lower, "://"); i >= 0 {
+		lower = lower[i+3:]
+	}
+
+	host, path := lower, ""
+	if i := strings.Index(lower, "/"); i >= 0 {
+		host, path = lower[:i], lower[i:]
+	}
+	if i := strings.LastIndex(host, ":"); i >= 0 {
+		host = host[:i]
+	}
+
+	for _, bypass := range rules.CSPBypasses {
+		if !hostsOverlap(host, bypass.Host) {
+			continue
+		}
+		if sourcePathAllows(path, bypass.Path) {
+			return bypass, true
+		}
+	}
+	return rules.CSPBypass{}, false
+}
+
+// sourcePathAllows reports whether a source path permits loading knownPath. An
+// empty knownPath means the whole host is affected, and a knownPath ending in
+// "/" covers every file below it.
+func sourcePathAllows(path, knownPath string) bool {
+	switch {
+	case path == "" || path == "/":
+		return true
+	case knownPath == "":
+		return false
+	case strings.HasSuffix(path, "/") && strings.HasPrefix(knownPath, path):
+		// A source path ending in "/" matches as a prefix
+		return true
+	case strings.HasSuffix(knownPath, "/"):
+		// Allowlisting a single file inside a gadget directory allows the gadget itself
+		return strings.HasPrefix(path, knownPath)
+	}
+	return path == knownPath
+}
+
+// hostsOverlap reports whether a CSP host source and a dataset host can match
+// the same name. Either side may use a leading "*." wildcard.
+func hostsOverlap(source, known string) bool {
+	switch {
+	case source == known:
+		return true
+	case strings.HasPrefix(source, "*."):
+		return strings.HasSuffix(strings.TrimPrefix(known, "*"), source[1:])
+	case strings.HasPrefix(known, "*."):
+		return strings.HasSuffix(source, known[1:])
+	}
+	return false
+}
+
+// isWildcardSource reports whether a source expression matches any host.
+func isWildcardSource(src string) bool {
+	switch strings.ToLower(src) {
+	case "*", "https:", "https://*", "http://*", "*:*":
+		return true
+	}
+	return false
+}
@@ -0,0 +1,122 @@
+package scanner
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/ismailtsdln/HeaderSentinel/internal/rules"
+)
+
+// ParseReportingEndpoints parses a Reporting-Endpoints header into a map of
+// group name to endpoint URL.
+func ParseReportingEndpoints(value string) map[string]string {
+	endpoints := map[string]string{}
+	for _, member := range strings.Split(value, ",") {
+		name, target, ok := strings.Cut(member, "=")
+		if !ok {
+			continue
+		}
+		endpoints[strings.TrimSpace(name)] = strings.Trim(strings.TrimSpace(target), "\"")
+	}
+	return endpoints
+}
+
+// reportToGroup is a single group of the legacy Report-To header.
+type reportToGroup struct {
+	Group     string `json:"group"`
+	Endpoints []struct {
+		URL string `json:"url"`
+	} `json:"endpoints"`
+}
+
+// ParseReportTo parses a legacy Report-To header into a map of group name to
+// endpoint URLs. Groups without a name belong to "default".
+func ParseReportTo(value string) map[string][]string {
+	groups := []reportToGroup{}
+	endpoints := map[string][]string{}
+	if err := json.Unmarshal([]byte("["+value+"]"), &groups); err != nil {
+		return endpoints
+	}
+	for _, g := range groups {
+		name := g.Group
+		if name == "" {
+			name = "default"
+		}
+		for _, e := range g.Endpoints {
+			endpoints[name] = append(endpoints[name], e.URL)
+		}
+	}
+	return endpoints
+}
+
+// analyzeCSPReporting validates the report-uri and report-to targets of a policy.
+func (s *HeaderScanner) analyzeCSPReporting(value string, reportOnly bool, header http.Header, findings *[]Finding) {
+	headerName := "Content-Security-Policy"
+	if reportOnly {
+		headerName = "Content-Security-Policy-Report-Only"
+	}
+
+	report := func(checkName, detail string) {
+		rule := rules.FindRule(rules.CSPRules, checkName)
+		finding := s.createFinding(rule, "misconfigured", rule.Risk)
+		finding.Header = headerName
+		finding.Description += " (" + detail + ")"
+		*findings = append(*findings, finding)
+	}
+
+	groups := map[string][]string{}
+	for name, target := range ParseReportingEndpoints(strings.Join(header.Values("Reporting-Endpoints"), ",")) {
+		groups[name] = []string{target}
+	}
+	for name, targets := range ParseReportTo(strings.Join(header.Values("Report-To"), ",")) {
+		groups[name] = append(groups[name], targets...)
+	}
+
+	for _, policy := range ParseCSP(value) {
+		reportURIs := policy.Directives["report-uri"]
+		reportTo := policy.Directives["report-to"]
+
+		if reportOnly && len(reportURIs) == 0 && len(reportTo) == 0 {
+			rule := rules.FindRule(rules.CSPRules, "CSP Report-Only Without Reporting")
+			*findings = append(*findings, s.createFinding(rule, "misconfigured", rule.Risk))
+			continue
+		}
+
+		for _, target := range reportURIs {
+			if problem := reportingURLProblem(target); problem != "" {
+				report("CSP Invalid Reporting Endpoint", fmt.Sprintf("report-uri %s %s", target, problem))
+			}
+		}
+
+		for _, group := range reportTo {
+			targets, ok := groups[group]
+			if !ok {
+				report("CSP Invalid Reporting Endpoint", fmt.Sprintf("report-to group %q is not declared in Reporting-Endpoints or Report-To", group))
+				continue
+			}
+			for _, target := range targets {
+				if problem := reportingURLProblem(target); problem != "" {
+					report("CSP Invalid Reporting Endpoint", fmt.Sprintf("report-to group %q endpoint %s %s", group, target, problem))
+				}
+			}
+		}
+	}
+}
+
+// reportingURLProblem describes why a reporting target is unusable, or returns
+// an empty string when it is an absolute HTTPS URL.
+func reportingURLProblem(target string) string {
+	u, err := url.Parse(target)
+	switch {
+	case err != nil:
+		return "is not a valid URL"
+	case !u.IsAbs():
+		return "is not an absolute URL"
+	case u.Scheme != "https":
+		return "does not use HTTPS"
+	}
+	return ""
+}
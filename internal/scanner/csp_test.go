@@ -0,0 +1,182 @@
+package scanner
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func TestParseCSP(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+		want  []CSPPolicy
+	}{
+		{
+			name:  "empty",
+			value: " ; , ",
+			want:  []CSPPolicy{},
+		},
+		{
+			name:  "single policy",
+			value: "default-src 'self'; Script-Src 'self' https://cdn.example; upgrade-insecure-requests",
+			want: []CSPPolicy{{
+				Names: []string{"default-src", "script-src", "upgrade-insecure-requests"},
+				Directives: map[string][]string{
+					"default-src":               {"'self'"},
+					"script-src":                {"'self'", "https://cdn.example"},
+					"upgrade-insecure-requests": {},
+				},
+			}},
+		},
+		{
+			name:  "duplicate directive keeps the first",
+			value: "script-src 'none'; script-src *",
+			want: []CSPPolicy{{
+				Names:      []string{"script-src"},
+				Directives: map[string][]string{"script-src": {"'none'"}},
+			}},
+		},
+		{
+			name:  "multiple policies",
+			value: "default-src 'self',object-src 'none'; base-uri 'none'",
+			want: []CSPPolicy{
+				{Names: []string{"default-src"}, Directives: map[string][]string{"default-src": {"'self'"}}},
+				{Names: []string{"object-src", "base-uri"}, Directives: map[string][]string{"object-src": {"'none'"}, "base-uri": {"'none'"}}},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ParseCSP(tt.value); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseCSP(%q) = %#v, want %#v", tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEffectiveSources(t *testing.T) {
+	tests := []struct {
+		policy    string
+		directive string
+		want      []string
+		from      string
+	}{
+		{"default-src 'self'", "script-src", []string{"'self'"}, "default-src"},
+		{"default-src 'self'; script-src 'none'", "script-src", []string{"'none'"}, "script-src"},
+		{"default-src 'self'; script-src 'none'", "script-src-elem", []string{"'none'"}, "script-src"},
+		{"default-src 'self'; child-src a.example", "frame-src", []string{"a.example"}, "child-src"},
+		{"script-src b.example", "worker-src", []string{"b.example"}, "script-src"},
+		{"default-src 'self'", "base-uri", nil, ""},
+		{"default-src 'self'; form-action 'none'", "form-action", []string{"'none'"}, "form-action"},
+		{"img-src *", "style-src", nil, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.policy+"/"+tt.directive, func(t *testing.T) {
+			got, from := ParseCSP(tt.policy)[0].EffectiveSources(tt.directive)
+			if !reflect.DeepEqual(got, tt.want) || from != tt.from {
+				t.Errorf("EffectiveSources(%q) = %q from %q, want %q from %q", tt.directive, got, from, tt.want, tt.from)
+			}
+		})
+	}
+}
+
+func TestFindCSPBypass(t *testing.T) {
+	tests := []struct {
+		src  string
+		want bool
+	}{
+		{"https://cdnjs.cloudflare.com", true},
+		{"raw.githubusercontent.com/example-org/", false},
+		{"ajax.googleapis.com", true},
+		{"ajax.googleapis.com/ajax/", true},
+		{"ajax.googleapis.com/ajax/libs/angularjs/1.8.2/angular.min.js", true},
+		{"ajax.googleapis.com/ajax/libs/jquery/", false},
+		{"https://ajax.googleapis.com:443/ajax/libs/angularjs/", true},
+		{"foo.github.io", true},
+		{"*.github.io", true},
+		{"github.io", false},
+		{"*.example.com", false},
+		{"'self'", false},
+		{"https:", false},
+		{"*", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.src, func(t *testing.T) {
+			if _, got := findCSPBypass(tt.src); got != tt.want {
+				t.Errorf("findCSPBypass(%q) = %v, want %v", tt.src, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAnalyzeCSP(t *testing.T) {
+	tests := []struct {
+		name       string
+		value      string
+		reportOnly bool
+		want       []string // rule IDs, sorted
+	}{
+		{
+			name:  "strict policy",
+			value: "script-src 'nonce-abc' 'strict-dynamic' https:; object-src 'none'; base-uri 'self'",
+			want:  []string{"HS-CSP-012"},
+		},
+		{
+			name:  "strict-dynamic in default-src only exempts scripts",
+			value: "default-src 'nonce-x' 'strict-dynamic' https: http://cdn.example; base-uri 'none'",
+			want:  []string{"HS-CSP-002", "HS-CSP-006"},
+		},
+		{
+			name:  "default-src fallback reported once",
+			value: "default-src *; base-uri 'none'",
+			want:  []string{"HS-CSP-002"},
+		},
+		{
+			name:  "unsafe-inline ignored next to a nonce",
+			value: "script-src 'unsafe-inline' 'nonce-abc' 'unsafe-eval'; object-src 'none'; base-uri 'none'",
+			want:  []string{"HS-CSP-008"},
+		},
+		{
+			name:  "unsafe sources",
+			value: "script-src 'unsafe-inline' data: http://cdn.example; object-src 'none'; base-uri 'none'",
+			want:  []string{"HS-CSP-003", "HS-CSP-006", "HS-CSP-007"},
+		},
+		{
+			name:  "bypassable host",
+			value: "script-src 'self' cdnjs.cloudflare.com; object-src 'none'; base-uri 'none'",
+			want:  []string{"HS-CSP-009"},
+		},
+		{
+			name:  "multiple policies cover object-src and base-uri together",
+			value: "object-src 'none', base-uri 'none'; frame-ancestors 'none'",
+			want:  []string{},
+		},
+		{
+			name:  "weakness in any policy is reported",
+			value: "frame-ancestors 'none', script-src 'unsafe-inline' data:",
+			want:  []string{"HS-CSP-003", "HS-CSP-004", "HS-CSP-005", "HS-CSP-007"},
+		},
+		{
+			name:       "report-only policy is not strict",
+			value:      "script-src 'nonce-abc' 'strict-dynamic'; object-src 'none'; base-uri 'none'",
+			reportOnly: true,
+			want:       []string{},
+		},
+	}
+	s := NewHeaderScanner()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			findings := []Finding{}
+			s.analyzeCSP(tt.value, tt.reportOnly, &findings)
+			got := []string{}
+			for _, f := range findings {
+				got = append(got, f.RuleID)
+			}
+			sort.Strings(got)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("analyzeCSP(%q) = %v, want %v", tt.value, got, tt.want)
+			}
+		})
+	}
+}
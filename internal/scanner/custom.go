@@ -0,0 +1,81 @@
+package scanner
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/ismailtsdln/HeaderSentinel/internal/rules"
+)
+
+// headerDirective looks up a directive in a header value. Directives are
+// separated by ";" or ",", and written as "name=value" (HSTS, Cache-Control)
+// or "name value" (CSP). Names are case-insensitive and quotes are removed.
+func headerDirective(value, name string) (string, bool) {
+	for _, part := range strings.FieldsFunc(value, func(r rune) bool { return r == ';' || r == ',' }) {
+		part = strings.TrimSpace(part)
+		// Split on whichever separator comes first, so "=" inside a CSP hash
+		// source such as 'sha256-abc=' stays part of the value
+		key, val := part, ""
+		if i := strings.IndexAny(part, "= \t"); i >= 0 {
+			key, val = part[:i], strings.TrimPrefix(strings.TrimSpace(part[i:]), "=")
+		}
+		if strings.EqualFold(key, name) {
+			return strings.Trim(strings.TrimSpace(val), "\""), true
+		}
+	}
+	return "", false
+}
+
+// analyzeCustomRules evaluates the declarative rules loaded from rule files.
+func (s *HeaderScanner) analyzeCustomRules(header http.Header, findings *[]Finding) {
+	for _, custom := range s.CustomRules {
+		rule := custom.SecurityRule()
+		c := custom.Condition
+
+		values := header.Values(custom.Header)
+		target, present := strings.Join(values, ", "), len(values) > 0
+		subject := custom.Header
+		if c.Directive != "" && present {
+			target, present = headerDirective(target, c.Directive)
+			subject += " " + c.Directive
+		}
+
+		problem := ""
+		switch {
+		case c.Type == rules.ConditionForbidden:
+			if present {
+				problem = subject + " must not be set"
+			}
+		case !present:
+			problem = subject + " is missing"
+		case c.Type == rules.ConditionEquals:
+			if !strings.EqualFold(target, c.ValueString()) {
+				problem = subject + " is " + target + ", expected " + c.ValueString()
+			}
+		case c.Type == rules.ConditionOneOf:
+			if !containsSource(c.Values, target) {
+				problem = subject + " is " + target + ", expected one of " + strings.Join(c.Values, ", ")
+			}
+		case c.Type == rules.ConditionRegex:
+			if !c.Pattern.MatchString(target) {
+				problem = subject + " " + target + " does not match " + c.ValueString()
+			}
+		case c.Type == rules.ConditionMin:
+			if n, err := strconv.ParseFloat(target, 64); err != nil || n < c.Minimum {
+				problem = subject + " is " + target + ", expected at least " + c.ValueString()
+			}
+		}
+		if problem == "" {
+			continue
+		}
+
+		status := "misconfigured"
+		if !present && c.Type != rules.ConditionForbidden && c.Directive == "" {
+			status = "missing"
+		}
+		finding := s.createFinding(rule, status, rule.Risk)
+		finding.Description += " (" + problem + ")"
+		*findings = append(*findings, finding)
+	}
+}
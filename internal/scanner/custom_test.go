@@ -0,0 +1,25 @@
+package scanner
+
+import "testing"
+
+func TestHeaderDirective(t *testing.T) {
+	tests := []struct {
+		value, name string
+		want        string
+		found       bool
+	}{
+		{"max-age=31536000; includeSubDomains", "max-age", "31536000", true},
+		{"max-age=31536000; includeSubDomains", "includesubdomains", "", true},
+		{"max-age = 300", "max-age", "300", true},
+		{"public, max-age=\"60\"", "max-age", "60", true},
+		{"default-src 'self'; script-src 'sha256-abc=' 'nonce-x'", "script-src", "'sha256-abc=' 'nonce-x'", true},
+		{"default-src 'self'", "script-src", "", false},
+		{"", "max-age", "", false},
+	}
+	for _, tt := range tests {
+		got, found := headerDirective(tt.value, tt.name)
+		if got != tt.want || found != tt.found {
+			t.Errorf("headerDirective(%q, %q) = %q, %v; want %q, %v", tt.value, tt.name, got, found, tt.want, tt.found)
+		}
+	}
+}
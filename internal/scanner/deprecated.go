@@ -0,0 +1,41 @@
+package scanner
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/ismailtsdln/HeaderSentinel/internal/rules"
+)
+
+func (s *HeaderScanner) analyzeDeprecatedHeaders(header http.Header, findings *[]Finding) {
+	for _, rule := range rules.DeprecatedHeaders {
+		value := header.Get(rule.Header)
+		if value == "" {
+			continue
+		}
+
+		risk := rule.Risk
+		detail := ""
+		switch rule.Header {
+		case "X-XSS-Protection":
+			// Disabling the auditor is the current recommendation
+			if strings.TrimSpace(value) == "0" {
+				continue
+			}
+		case "Feature-Policy":
+			// Without a replacement the old header is the only policy older browsers see
+			if header.Get("Permissions-Policy") == "" {
+				risk = rules.RiskLow
+				detail = "no Permissions-Policy header is set"
+			}
+		}
+
+		finding := s.createFinding(rule, "deprecated", risk)
+		finding.Description += " (" + rule.Header + ": " + value
+		if detail != "" {
+			finding.Description += ", " + detail
+		}
+		finding.Description += ")"
+		*findings = append(*findings, finding)
+	}
+}
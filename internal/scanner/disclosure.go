@@ -0,0 +1,136 @@
+package scanner
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/ismailtsdln/HeaderSentinel/internal/rules"
+)
+
+// ProductVersion is a product name and, if disclosed, its version.
+type ProductVersion struct {
+	Product string
+	Version string
+}
+
+// ExtractProducts extracts product tokens such as "Apache/2.2.15" or
+// "Drupal 7" from a header value. Comments in parentheses are included, so
+// "Apache/2.2.15 (CentOS) PHP/5.3.3" yields Apache, CentOS and PHP.
+func ExtractProducts(value string) []ProductVersion {
+	products := []ProductVersion{}
+	fields := strings.FieldsFunc(value, func(r rune) bool {
+		return r == ' ' || r == '\t' || r == '(' || r == ')' || r == ',' || r == ';'
+	})
+
+	for _, field := range fields {
+		if strings.Contains(field, "://") {
+			continue
+		}
+		if name, version, ok := strings.Cut(field, "/"); ok {
+			if name != "" && isAlpha(name[0]) {
+				products = append(products, ProductVersion{Product: name, Version: cleanVersion(version)})
+			}
+			continue
+		}
+		if isVersion(field) {
+			// A bare number right after a product name is its version, as in "Drupal 7"
+			if n := len(products); n > 0 && products[n-1].Version == "" {
+				products[n-1].Version = cleanVersion(field)
+			}
+			continue
+		}
+		if isAlpha(field[0]) {
+			products = append(products, ProductVersion{Product: field})
+		}
+	}
+	return products
+}
+
+// isVersion reports whether s looks like a version number, e.g. "7", "2.4.1" or "v1.2".
+func isVersion(s string) bool {
+	s = strings.TrimPrefix(strings.ToLower(s), "v")
+	return s != "" && isDigit(s[0])
+}
+
+// cleanVersion strips a leading "v" and returns an empty string for values
+// that are not version numbers.
+func cleanVersion(s string) string {
+	if !isVersion(s) {
+		return ""
+	}
+	return strings.TrimPrefix(strings.ToLower(s), "v")
+}
+
+// versionRisk rates a disclosed version: a bare product name is INFO, a
+// major or major.minor version is LOW and a full patch-level version is MEDIUM.
+func versionRisk(version string) rules.RiskLevel {
+	switch {
+	case version == "":
+		return rules.RiskInfo
+	case strings.Count(version, ".") >= 2:
+		return rules.RiskMedium
+	}
+	return rules.RiskLow
+}
+
+// analyzeDisclosure reports a header that reveals the technology stack. The
+// most specific disclosed product and version are recorded in the finding.
+func (s *HeaderScanner) analyzeDisclosure(rule rules.SecurityRule, value string, findings *[]Finding) {
+	var products []ProductVersion
+	if product, ok := rules.VersionHeaderProducts[rule.Header]; ok {
+		products = []ProductVersion{{Product: product, Version: cleanVersion(strings.TrimSpace(value))}}
+	} else if rule.Header == "Via" {
+		// Each Via entry starts with the protocol version, e.g. "1.1 varnish"
+		for _, entry := range strings.Split(value, ",") {
+			if fields := strings.Fields(entry); len(fields) > 1 {
+				products = append(products, ExtractProducts(strings.Join(fields[1:], " "))...)
+			}
+		}
+	} else if !rules.FixedRiskHeaders[rule.Header] {
+		products = ExtractProducts(value)
+	}
+
+	risk := rule.Risk
+	primary := ProductVersion{}
+	if !rules.FixedRiskHeaders[rule.Header] {
+		risk = rules.RiskInfo
+		for _, p := range products {
+			if primary.Product == "" || (primary.Version == "" && p.Version != "") {
+				primary = p
+			}
+			if r := versionRisk(p.Version); riskRank(r) > riskRank(risk) {
+				risk = r
+			}
+		}
+	}
+
+	finding := s.createFinding(rule, "present", risk)
+	finding.Product = primary.Product
+	finding.Version = primary.Version
+	finding.Description += " (" + rule.Header + ": " + value + ")"
+	*findings = append(*findings, finding)
+}
+
+// analyzeDisclosureHeaders reports the disclosure headers outside the main rule list.
+func (s *HeaderScanner) analyzeDisclosureHeaders(header http.Header, findings *[]Finding) {
+	for _, rule := range rules.DisclosureHeaders {
+		for _, value := range header.Values(rule.Header) {
+			s.analyzeDisclosure(rule, value, findings)
+		}
+	}
+}
+
+// riskRank orders risk levels from INFO (0) to CRITICAL (4).
+func riskRank(risk rules.RiskLevel) int {
+	switch risk {
+	case rules.RiskCritical:
+		return 4
+	case rules.RiskHigh:
+		return 3
+	case rules.RiskMedium:
+		return 2
+	case rules.RiskLow:
+		return 1
+	}
+	return 0
+}
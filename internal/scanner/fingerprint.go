@@ -0,0 +1,189 @@
+package scanner
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/ismailtsdln/HeaderSentinel/internal/rules"
+)
+
+// Technology is a technology identified from response headers.
+type Technology struct {
+	Name           string
+	Category       string
+	Version        string
+	Header         string // header that revealed the technology
+	Evidence       string
+	EndOfLife      bool
+	SupportUnknown bool // version too coarse to compare with eol_before, e.g. "2" against "2.4"
+	CVEs           []string
+	Severity       rules.RiskLevel // highest severity of the matched CVEs
+}
+
+// Fingerprint identifies the technology stack from the embedded header
+// signatures and cross-references disclosed versions with the scanner's
+// vulnerability feed. It makes no network requests.
+func (s *HeaderScanner) Fingerprint(header http.Header) []Technology {
+	cookies := map[string]bool{}
+	for _, value := range header.Values("Set-Cookie") {
+		if cookie, ok := parseSetCookie(value); ok {
+			cookies[strings.ToLower(cookie.Name)] = true
+		}
+	}
+
+	technologies := []Technology{}
+	for _, fp := range rules.Fingerprints {
+		tech, ok := matchFingerprint(fp, header, cookies)
+		if !ok {
+			continue
+		}
+		if tech.Version != "" {
+			if fp.EOLBefore != "" {
+				before, known := versionBefore(tech.Version, fp.EOLBefore)
+				tech.EndOfLife, tech.SupportUnknown = before, !known
+			}
+			for _, vuln := range s.Vulnerabilities {
+				if strings.EqualFold(vuln.Product, fp.Name) && versionAffected(tech.Version, vuln) {
+					tech.CVEs = append(tech.CVEs, vuln.ID)
+					severity := vuln.Severity
+					if severity == "" {
+						severity = rules.RiskHigh
+					}
+					if riskRank(severity) > riskRank(tech.Severity) {
+						tech.Severity = severity
+					}
+				}
+			}
+		}
+		technologies = append(technologies, tech)
+	}
+	return technologies
+}
+
+// matchFingerprint checks the header patterns of a signature in a stable order
+// and prefers a match that captured a version.
+func matchFingerprint(fp rules.Fingerprint, header http.Header, cookies map[string]bool) (Technology, bool) {
+	tech := Technology{Name: fp.Name, Category: fp.Category}
+	matched := false
+
+	names := make([]string, 0, len(fp.Patterns))
+	for name := range fp.Patterns {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		for _, value := range header.Values(name) {
+			m := fp.Patterns[name].FindStringSubmatch(value)
+			if m == nil {
+				continue
+			}
+			version := ""
+			if len(m) > 1 {
+				version = m[1]
+			}
+			if !matched || (tech.Version == "" && version != "") {
+				tech.Version = version
+				tech.Header = name
+				tech.Evidence = name + ": " + value
+			}
+			matched = true
+		}
+	}
+
+	for _, name := range fp.Cookies {
+		if cookies[strings.ToLower(name)] && !matched {
+			tech.Header = "Set-Cookie"
+			tech.Evidence = "cookie " + name
+			matched = true
+		}
+	}
+	return tech, matched
+}
+
+// analyzeTechnologies reports identified technologies whose version is
+// end-of-life or has known vulnerabilities.
+func (s *HeaderScanner) analyzeTechnologies(technologies []Technology, findings *[]Finding) {
+	rule := rules.FindRule(rules.FingerprintRules, "Outdated Technology")
+	for _, tech := range technologies {
+		if !tech.EndOfLife && len(tech.CVEs) == 0 {
+			continue
+		}
+
+		risk := rules.RiskLevel("")
+		problems := []string{}
+		if tech.EndOfLife {
+			risk = rule.Risk
+			problems = append(problems, "end-of-life")
+		}
+		if len(tech.CVEs) > 0 {
+			problems = append(problems, fmt.Sprintf("%d known CVEs (%s)", len(tech.CVEs), strings.Join(tech.CVEs, ", ")))
+			if riskRank(tech.Severity) > riskRank(risk) {
+				risk = tech.Severity
+			}
+		}
+
+		finding := s.createFinding(rule, "misconfigured", risk)
+		finding.Header = tech.Header
+		finding.Product = tech.Name
+		finding.Version = tech.Version
+		finding.Description += fmt.Sprintf(" (%s %s disclosed — %s)", tech.Name, tech.Version, strings.Join(problems, ", "))
+		*findings = append(*findings, finding)
+	}
+}
+
+// versionAffected reports whether version lies in the affected range of a vulnerability.
+func versionAffected(version string, vuln rules.Vulnerability) bool {
+	if vuln.Introduced != "" && compareVersions(version, vuln.Introduced) < 0 {
+		return false
+	}
+	return vuln.Fixed == "" || compareVersions(version, vuln.Fixed) < 0
+}
+
+// compareVersions compares dotted version numbers numerically, returning -1, 0
+// or 1. Missing components count as zero and non-numeric suffixes such as the
+// "k" in "1.0.2k" are ignored.
+func compareVersions(a, b string) int {
+	as, bs := strings.Split(a, "."), strings.Split(b, ".")
+	for i := 0; i < len(as) || i < len(bs); i++ {
+		x, y := versionComponent(as, i), versionComponent(bs, i)
+		switch {
+		case x < y:
+			return -1
+		case x > y:
+			return 1
+		}
+	}
+	return 0
+}
+
+// versionBefore reports whether version is lower than bound. known is false
+// when version has fewer components than bound and all of them match, as in
+// "2" against "2.4", so the result cannot be decided.
+func versionBefore(version, bound string) (before, known bool) {
+	vs, bs := strings.Split(version, "."), strings.Split(bound, ".")
+	for i := range bs {
+		if i >= len(vs) {
+			return false, false
+		}
+		if x, y := versionComponent(vs, i), versionComponent(bs, i); x != y {
+			return x < y, true
+		}
+	}
+	return false, true
+}
+
+func versionComponent(parts []string, i int) int {
+	if i >= len(parts) {
+		return 0
+	}
+	end := 0
+	for end < len(parts[i]) && isDigit(parts[i][end]) {
+		end++
+	}
+	n, _ := strconv.Atoi(parts[i][:end])
+	return n
+}
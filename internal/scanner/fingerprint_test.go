@@ -0,0 +1,66 @@
+package scanner
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestVersionBefore(t *testing.T) {
+	tests := []struct {
+		version, bound string
+		before, known  bool
+	}{
+		{"2.2.15", "2.4", true, true},
+		{"2.4", "2.4", false, true},
+		{"2.4.58", "2.4", false, true},
+		{"1.3", "2.4", true, true},
+		{"2", "2.4", false, false},
+		{"1", "2.4", true, true},
+		{"3", "2.4", false, true},
+		{"8", "8.1", false, false},
+		{"7", "8.1", true, true},
+		{"1.0.2k", "3.0", true, true},
+		{"8.5", "8.5", false, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.version+"<"+tt.bound, func(t *testing.T) {
+			before, known := versionBefore(tt.version, tt.bound)
+			if before != tt.before || known != tt.known {
+				t.Errorf("versionBefore(%q, %q) = %v, %v, want %v, %v", tt.version, tt.bound, before, known, tt.before, tt.known)
+			}
+		})
+	}
+}
+
+func TestFingerprintEndOfLife(t *testing.T) {
+	tests := []struct {
+		header, value, product string
+		endOfLife, unknown     bool
+	}{
+		{"Server", "Apache/2", "Apache HTTP Server", false, true},
+		{"Server", "Apache/2.2", "Apache HTTP Server", true, false},
+		{"Server", "Apache/2.4.62 (Debian)", "Apache HTTP Server", false, false},
+		{"X-Powered-By", "PHP/8", "PHP", false, true},
+		{"X-Powered-By", "PHP/7", "PHP", true, false},
+		{"X-Powered-By", "PHP/8.3.4", "PHP", false, false},
+		// The CLR version is 4.0.30319 for every .NET Framework from 4.5 to 4.8.1
+		{"X-AspNet-Version", "4.0.30319", "ASP.NET", false, false},
+	}
+	s := NewHeaderScanner()
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			header := http.Header{}
+			header.Set(tt.header, tt.value)
+			for _, tech := range s.Fingerprint(header) {
+				if tech.Name != tt.product {
+					continue
+				}
+				if tech.EndOfLife != tt.endOfLife || tech.SupportUnknown != tt.unknown {
+					t.Errorf("%s %s: EndOfLife = %v, SupportUnknown = %v, want %v, %v", tech.Name, tech.Version, tech.EndOfLife, tech.SupportUnknown, tt.endOfLife, tt.unknown)
+				}
+				return
+			}
+			t.Errorf("%s: %s not identified", tt.value, tt.product)
+		})
+	}
+}
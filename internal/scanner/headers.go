@@ -2,124 +2,104 @@ package scanner
 
 import (
 	"net/http"
-	"strconv"
-	"strings"
 
 	"github.com/ismailtsdln/HeaderSentinel/internal/rules"
 )
 
 // Finding represents a single security finding.
 type Finding struct {
+	RuleID         string // stable rule identifier, e.g. HS-HSTS-001
 	Header         string
-	Status         string // present / missing / misconfigured
+	Cookie         string // cookie name, for Set-Cookie findings
+	Classification string // cookie class and the reason for it, e.g. "session: JWT-shaped value"
+	Product        string // disclosed product, for information disclosure findings
+	Version        string // disclosed product version, if any
+	Status         string // present / missing / misconfigured / deprecated / meta / mitigated
 	Risk           rules.RiskLevel
 	Description    string
 	Recommendation string
 	Exploit        string
 	NginxConfig    string
 	ApacheConfig   string
+	CWE            string
+	OWASP          string
+	ASVS           string
+	DocURL         string
 }
 
 // HeaderScanner analyzes response headers.
 type HeaderScanner struct {
-	Rules []rules.SecurityRule
+	Rules            []rules.SecurityRule
+	DisabledFeatures []string              // Permissions-Policy features that must be set to ()
+	Vulnerabilities  []rules.Vulnerability // optional local feed for fingerprinted versions
+	CustomRules      []rules.CustomRule    // declarative rules loaded from rule files
+	Checks           []Check               // checks run by Scan, in order
+	Profile          rules.Profile         // rule profile applied to findings
+	Client           *http.Client          // client for checks that send requests; nil keeps scans passive
+	ProbeCORS        bool                  // re-request targets with crafted Origin headers, needs Client
 }
 
 // NewHeaderScanner creates a new header scanner.
 func NewHeaderScanner() *HeaderScanner {
-	return &HeaderScanner{
+	s := &HeaderScanner{
 		Rules: rules.SecurityHeaders,
 	}
+	s.Checks = s.builtinChecks()
+	for _, check := range RegisteredChecks() {
+		s.Register(check)
+	}
+	return s
 }
 
 // Scan analyzes the headers of an HTTP response.
 func (s *HeaderScanner) Scan(resp *http.Response) []Finding {
+	return s.ScanTarget(NewTarget(resp, RedirectResult{}))
+}
+
+// ScanTarget runs every check of the scanner against a target.
+func (s *HeaderScanner) ScanTarget(target *Target) []Finding {
+	if target.Rules == nil {
+		target.Rules = s.Rules
+	}
 	findings := []Finding{}
+	for _, check := range s.Checks {
+		findings = append(findings, check.Run(target)...)
+	}
+	return s.applyProfile(findings)
+}
 
-	for _, rule := range s.Rules {
-		headerName := rule.Header
-		values := resp.Header.Values(headerName)
+// UseProfile selects a rule profile. It narrows the header rules the scanner
+// checks and adjusts the risk of findings reported at their rule's risk.
+func (s *HeaderScanner) UseProfile(profile rules.Profile) {
+	s.Profile = profile
+	s.Rules = profile.Apply(s.Rules)
+}
 
-		if len(values) == 0 {
-			// If header is missing, it's only a risk for required security headers
-			if headerName != "Server" && headerName != "X-Powered-By" && headerName != "Set-Cookie" {
-				finding := s.createFinding(rule, "missing", rule.Risk)
-				findings = append(findings, finding)
-			}
+// applyProfile drops the findings of disabled rules and applies adjusted risks.
+// A risk the check derived from the status, such as a policy delivered via
+// <meta> or a de-escalated preference cookie, is kept.
+func (s *HeaderScanner) applyProfile(findings []Finding) []Finding {
+	kept := findings[:0]
+	for _, f := range findings {
+		if !s.Profile.Enabled(f.RuleID) {
 			continue
 		}
-
-		for _, value := range values {
-			finding := s.createFinding(rule, "present", rules.RiskInfo)
-
-			// Special handling for Set-Cookie
-			if headerName == "Set-Cookie" {
-				s.analyzeCookie(value, rule, &findings)
-				continue
-			}
-
-			// Basic misconfiguration checks
-			switch headerName {
-			case "Strict-Transport-Security":
-				if !strings.Contains(value, "max-age") {
-					finding.Status = "misconfigured"
-					finding.Risk = rules.RiskMedium
-					finding.Description += " (Missing max-age)"
-					findings = append(findings, finding)
-				} else {
-					// Check for at least 1 year (31536000 seconds)
-					if !s.checkHSTSMaxAge(value) {
-						finding.Status = "misconfigured"
-						finding.Risk = rules.RiskLow
-						finding.Description += " (max-age too short, should be >= 1 year)"
-						findings = append(findings, finding)
-					}
-				}
-				if !strings.Contains(value, "includeSubDomains") {
-					finding.Status = "misconfigured"
-					finding.Risk = rules.RiskLow
-					finding.Description += " (Missing includeSubDomains)"
-					findings = append(findings, finding)
-				}
-				if !strings.Contains(value, "preload") {
-					finding.Status = "present"
-					finding.Risk = rules.RiskInfo
-					finding.Description += " (Preload directive not found)"
-					findings = append(findings, finding)
-				}
-			case "X-Frame-Options":
-				v := strings.ToUpper(value)
-				if v != "DENY" && v != "SAMEORIGIN" {
-					finding.Status = "misconfigured"
-					finding.Risk = rules.RiskMedium
-					findings = append(findings, finding)
-				}
-			case "X-Content-Type-Options":
-				if strings.ToLower(value) != "nosniff" {
-					finding.Status = "misconfigured"
-					finding.Risk = rules.RiskLow
-					findings = append(findings, finding)
-				}
-			case "Server", "X-Powered-By":
-				finding.Status = "present"
-				finding.Risk = rules.RiskLow // Presence of these headers is a low risk information disclosure
-				findings = append(findings, finding)
-			case "Content-Security-Policy":
-				if strings.Contains(value, "unsafe-inline") || strings.Contains(value, "unsafe-eval") {
-					finding.Status = "misconfigured"
-					finding.Risk = rules.RiskMedium
-					finding.Description += " (Unsafe directives detected)"
-					findings = append(findings, finding)
-				}
-			}
+		if rule, ok := rules.FindRuleByID(f.RuleID); !ok || f.Risk == rule.Risk {
+			f.Risk = s.Profile.Risk(f.RuleID, f.Risk)
 		}
+		kept = append(kept, f)
 	}
-
-	return findings
+	return kept
 }
 
 func (s *HeaderScanner) createFinding(rule rules.SecurityRule, status string, risk rules.RiskLevel) Finding {
+	return newFinding(rule, status, risk)
+}
+
+// newFinding creates a finding carrying the metadata of a rule.
+func newFinding(rule rules.SecurityRule, status string, risk rules.RiskLevel) Finding {
 	return Finding{
+		RuleID:         rule.ID,
 		Header:         rule.Header,
 		Status:         status,
 		Risk:           risk,
@@ -128,43 +108,9 @@ func (s *HeaderScanner) createFinding(rule rules.SecurityRule, status string, ri
 		Exploit:        rule.Exploit,
 		NginxConfig:    rule.NginxConfig,
 		ApacheConfig:   rule.ApacheConfig,
+		CWE:            rule.CWE,
+		OWASP:          rule.OWASP,
+		ASVS:           rule.ASVS,
+		DocURL:         rule.DocURL,
 	}
 }
-
-func (s *HeaderScanner) analyzeCookie(value string, rule rules.SecurityRule, findings *[]Finding) {
-	lowerValue := strings.ToLower(value)
-
-	switch rule.CheckName {
-	case "Insecure Cookie (Missing HttpOnly)":
-		if !strings.Contains(lowerValue, "httponly") {
-			finding := s.createFinding(rule, "misconfigured", rules.RiskMedium)
-			*findings = append(*findings, finding)
-		}
-	case "Insecure Cookie (Missing Secure)":
-		if !strings.Contains(lowerValue, "secure") {
-			finding := s.createFinding(rule, "misconfigured", rules.RiskMedium)
-			*findings = append(*findings, finding)
-		}
-	case "Insecure Cookie (Missing SameSite)":
-		if !strings.Contains(lowerValue, "samesite") {
-			finding := s.createFinding(rule, "misconfigured", rules.RiskLow)
-			*findings = append(*findings, finding)
-		}
-	}
-}
-
-func (s *HeaderScanner) checkHSTSMaxAge(value string) bool {
-	parts := strings.Split(value, ";")
-	for _, part := range parts {
-		part = strings.TrimSpace(part)
-		if strings.HasPrefix(part, "max-age=") {
-			ageStr := strings.TrimPrefix(part, "max-age=")
-			age, err := strconv.Atoi(ageStr)
-			if err != nil {
-				return false
-			}
-			return age >= 31536000
-		}
-	}
-	return false
-}
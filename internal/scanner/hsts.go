@@ -0,0 +1,212 @@
+package scanner
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/ismailtsdln/HeaderSentinel/internal/rules"
+)
+
+// hstsMinMaxAge is the one year max-age recommended for HSTS and required for preloading.
+const hstsMinMaxAge = 31536000
+
+// HSTSPolicy represents a parsed Strict-Transport-Security header.
+type HSTSPolicy struct {
+	MaxAge            int
+	HasMaxAge         bool
+	InvalidMaxAge     string // raw max-age value that is not a valid number
+	IncludeSubDomains bool
+	Preload           bool
+	Duplicates        []string // directive names that appear more than once
+}
+
+// ParseHSTS parses a Strict-Transport-Security header value as described in
+// RFC 6797 section 6.1. Directive names are case-insensitive and values may be
+// quoted strings.
+func ParseHSTS(value string) HSTSPolicy {
+	policy := HSTSPolicy{}
+	seen := map[string]bool{}
+
+	for _, directive := range strings.Split(value, ";") {
+		name, val, _ := strings.Cut(directive, "=")
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" {
+			continue
+		}
+		if seen[name] {
+			policy.Duplicates = append(policy.Duplicates, name)
+			continue
+		}
+		seen[name] = true
+
+		val = strings.TrimSpace(val)
+		if len(val) >= 2 && strings.HasPrefix(val, "\"") && strings.HasSuffix(val, "\"") {
+			val = val[1 : len(val)-1]
+		}
+
+		switch name {
+		case "max-age":
+			policy.HasMaxAge = true
+			age, err := strconv.Atoi(val)
+			if err != nil || age < 0 {
+				policy.InvalidMaxAge = val
+				continue
+			}
+			policy.MaxAge = age
+		case "includesubdomains":
+			policy.IncludeSubDomains = true
+		case "preload":
+			policy.Preload = true
+		}
+	}
+	return policy
+}
+
+// Valid reports whether browsers would honour the policy.
+func (p HSTSPolicy) Valid() bool {
+	return p.HasMaxAge && p.InvalidMaxAge == "" && len(p.Duplicates) == 0
+}
+
+func (s *HeaderScanner) analyzeHSTS(value string, findings *[]Finding) {
+	policy := ParseHSTS(value)
+
+	report := func(checkName, detail string) {
+		rule := rules.FindRule(rules.HSTSRules, checkName)
+		status := "misconfigured"
+		if rule.Risk == rules.RiskInfo {
+			status = "present"
+		}
+		finding := s.createFinding(rule, status, rule.Risk)
+		if detail != "" {
+			finding.Description += " (" + detail + ")"
+		}
+		*findings = append(*findings, finding)
+	}
+
+	// Browsers ignore a header with repeated directives, so the other
+	// directives have no effect to report on
+	if len(policy.Duplicates) > 0 {
+		for _, name := range policy.Duplicates {
+			report("HSTS Duplicate Directive", name)
+		}
+		return
+	}
+
+	switch {
+	case !policy.HasMaxAge:
+		report("HSTS Missing max-age", "")
+	case policy.InvalidMaxAge != "":
+		report("HSTS Missing max-age", fmt.Sprintf("max-age value %q is not a valid number", policy.InvalidMaxAge))
+	case policy.MaxAge < hstsMinMaxAge:
+		report("HSTS Short max-age", fmt.Sprintf("max-age=%d", policy.MaxAge))
+	}
+	if !policy.IncludeSubDomains {
+		report("HSTS Missing includeSubDomains", "")
+	}
+	if !policy.Preload {
+		report("HSTS Missing preload", "")
+	}
+}
+
+// HSTSPreloadResult describes whether a host meets the hstspreload.org
+// submission requirements.
+type HSTSPreloadResult struct {
+	Host     string
+	Eligible bool
+	Problems []string
+}
+
+// hstsPreload evaluates preload eligibility of the final HTTPS response
+// together with the redirect chain traced from http:// on the same host. An
+// empty chain means nothing is listening on port 80, which is allowed. A
+// finding is added only when the header asks for preloading but is ineligible.
+func (s *HeaderScanner) hstsPreload(resp *http.Response, httpRedirects RedirectResult, findings *[]Finding) HSTSPreloadResult {
+	result := HSTSPreloadResult{}
+	if resp.Request == nil || resp.Request.URL == nil {
+		return result
+	}
+	result.Host = strings.ToLower(resp.Request.URL.Hostname())
+
+	if resp.Request.URL.Scheme != "https" {
+		result.Problems = append(result.Problems, "final response is not served over HTTPS")
+	}
+	if !isApexDomain(result.Host) {
+		result.Problems = append(result.Problems, fmt.Sprintf("%s is not an apex domain", result.Host))
+	}
+	if problem := httpRedirectProblem(result.Host, httpRedirects); problem != "" {
+		result.Problems = append(result.Problems, problem)
+	}
+
+	value := resp.Header.Get("Strict-Transport-Security")
+	policy := ParseHSTS(value)
+	switch {
+	case value == "":
+		result.Problems = append(result.Problems, "Strict-Transport-Security header is missing")
+	case !policy.Valid():
+		result.Problems = append(result.Problems, "Strict-Transport-Security header is invalid")
+	default:
+		if policy.MaxAge < hstsMinMaxAge {
+			result.Problems = append(result.Problems, "max-age is below 31536000")
+		}
+		if !policy.IncludeSubDomains {
+			result.Problems = append(result.Problems, "includeSubDomains is missing")
+		}
+		if !policy.Preload {
+			result.Problems = append(result.Problems, "preload is missing")
+		}
+	}
+
+	result.Eligible = len(result.Problems) == 0
+	if !result.Eligible && policy.Preload {
+		rule := rules.FindRule(rules.HSTSRules, "HSTS Preload Ineligible")
+		finding := s.createFinding(rule, "misconfigured", rule.Risk)
+		finding.Description += " (" + strings.Join(result.Problems, "; ") + ")"
+		*findings = append(*findings, finding)
+	}
+	return result
+}
+
+// httpRedirectProblem checks that the first hop of a chain traced from http://host
+// redirects to https:// on the same host.
+func httpRedirectProblem(host string, redirects RedirectResult) string {
+	if len(redirects.Chain) == 0 {
+		return ""
+	}
+	if len(redirects.Chain) < 2 {
+		return "http:// does not redirect to https://"
+	}
+	next, err := url.Parse(redirects.Chain[1].URL)
+	if err != nil || next.Scheme != "https" {
+		return "http:// does not redirect to https:// first"
+	}
+	if !strings.EqualFold(next.Hostname(), host) {
+		return fmt.Sprintf("http:// redirects to %s instead of https://%s first", next.Hostname(), host)
+	}
+	return ""
+}
+
+// secondLevelLabels lists common second-level labels used under country code TLDs.
+var secondLevelLabels = map[string]bool{
+	"co": true, "com": true, "net": true, "org": true, "gov": true, "edu": true, "ac": true,
+}
+
+// isApexDomain reports whether host looks like a registrable domain rather than
+// a subdomain. Without a public suffix list, a two-letter TLD preceded by a
+// common second-level label (co.uk, com.tr, ...) is treated as one suffix.
+func isApexDomain(host string) bool {
+	if net.ParseIP(host) != nil {
+		return false
+	}
+	labels := strings.Split(strings.TrimSuffix(host, "."), ".")
+	switch len(labels) {
+	case 2:
+		return true
+	case 3:
+		return len(labels[2]) == 2 && secondLevelLabels[labels[1]]
+	}
+	return false
+}
@@ -0,0 +1,38 @@
+package scanner
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func TestAnalyzeHSTS(t *testing.T) {
+	tests := []struct {
+		value string
+		want  []string // rule IDs, sorted
+	}{
+		{"max-age=63072000; includeSubDomains; preload", []string{}},
+		{"max-age=300", []string{"HS-HSTS-003", "HS-HSTS-004", "HS-HSTS-005"}},
+		{`Max-Age="63072000"; INCLUDESUBDOMAINS`, []string{"HS-HSTS-005"}},
+		{"includeSubDomains", []string{"HS-HSTS-002", "HS-HSTS-005"}},
+		{"max-age=abc; includeSubDomains; preload", []string{"HS-HSTS-002"}},
+		// Browsers ignore the whole header, so only the duplicate is reported
+		{"max-age=300; max-age=600", []string{"HS-HSTS-006"}},
+		{"max-age=300; preload; preload; includeSubDomains; includeSubDomains", []string{"HS-HSTS-006", "HS-HSTS-006"}},
+	}
+	s := NewHeaderScanner()
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			findings := []Finding{}
+			s.analyzeHSTS(tt.value, &findings)
+			got := []string{}
+			for _, f := range findings {
+				got = append(got, f.RuleID)
+			}
+			sort.Strings(got)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("analyzeHSTS(%q) = %v, want %v", tt.value, got, tt.want)
+			}
+		})
+	}
+}
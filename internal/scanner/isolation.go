@@ -0,0 +1,74 @@
+package scanner
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/ismailtsdln/HeaderSentinel/internal/rules"
+)
+
+// crossOriginSafeValues lists the values of each Cross-Origin-* header that
+// provide isolation. Any other value is reported as misconfigured.
+var crossOriginSafeValues = map[string][]string{
+	"Cross-Origin-Opener-Policy":   {"same-origin"},
+	"Cross-Origin-Embedder-Policy": {"require-corp", "credentialless"},
+	"Cross-Origin-Resource-Policy": {"same-origin", "same-site"},
+}
+
+// IsolationResult is the cross-origin isolation verdict for a response.
+// SharedArrayBuffer and high-resolution timers require Isolated.
+type IsolationResult struct {
+	Isolated           bool
+	COOP               string
+	COEP               string
+	OriginAgentCluster bool
+	Problems           []string
+}
+
+// crossOriginValue returns the policy token of a Cross-Origin-* header value,
+// without parameters such as report-to.
+func crossOriginValue(value string) string {
+	token, _, _ := strings.Cut(value, ";")
+	return strings.ToLower(strings.TrimSpace(token))
+}
+
+func (s *HeaderScanner) analyzeCrossOrigin(rule rules.SecurityRule, value string, findings *[]Finding) {
+	token := crossOriginValue(value)
+	if containsSource(crossOriginSafeValues[rule.Header], token) {
+		return
+	}
+	finding := s.createFinding(rule, "misconfigured", rule.Risk)
+	finding.Description += " (value " + token + " does not isolate the document)"
+	*findings = append(*findings, finding)
+}
+
+// AssessCrossOriginIsolation reports whether a response is cross-origin
+// isolated: COOP same-origin together with COEP require-corp or credentialless.
+// Origin-Agent-Cluster is implied by isolation, and otherwise recorded as sent.
+func AssessCrossOriginIsolation(header http.Header) IsolationResult {
+	result := IsolationResult{
+		COOP: crossOriginValue(header.Get("Cross-Origin-Opener-Policy")),
+		COEP: crossOriginValue(header.Get("Cross-Origin-Embedder-Policy")),
+	}
+
+	if result.COOP != "same-origin" {
+		result.Problems = append(result.Problems, "Cross-Origin-Opener-Policy is not same-origin")
+	}
+	if result.COEP != "require-corp" && result.COEP != "credentialless" {
+		result.Problems = append(result.Problems, "Cross-Origin-Embedder-Policy is not require-corp or credentialless")
+	}
+	result.Isolated = len(result.Problems) == 0
+
+	switch strings.TrimSpace(header.Get("Origin-Agent-Cluster")) {
+	case "?1":
+		result.OriginAgentCluster = true
+	case "?0":
+		if result.Isolated {
+			result.Problems = append(result.Problems, "Origin-Agent-Cluster: ?0 is ignored because the document is isolated")
+		}
+	}
+	if result.Isolated {
+		result.OriginAgentCluster = true
+	}
+	return result
+}
@@ -0,0 +1,177 @@
+package scanner
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ismailtsdln/HeaderSentinel/internal/rules"
+)
+
+// jwtMaxLifetime is the token lifetime above which a JWT is reported as long-lived.
+const jwtMaxLifetime = 7 * 24 * time.Hour
+
+// jwtSensitiveClaims lists claim names that expose personal data or
+// authorization details, in lower case.
+var jwtSensitiveClaims = map[string]bool{
+	"email":        true,
+	"phone":        true,
+	"phone_number": true,
+	"address":      true,
+	"birthdate":    true,
+	"name":         true,
+	"role":         true,
+	"roles":        true,
+	"groups":       true,
+	"permissions":  true,
+	"is_admin":     true,
+	"admin":        true,
+	"password":     true,
+	"secret":       true,
+}
+
+// JWT is a decoded, unverified JSON Web Token.
+type JWT struct {
+	Header map[string]any
+	Claims map[string]any
+}
+
+// DecodeJWT decodes the header and claims of a compact JWT without verifying
+// its signature. A "Bearer " prefix is ignored.
+func DecodeJWT(token string) (JWT, error) {
+	token = strings.TrimSpace(token)
+	if len(token) > 7 && strings.EqualFold(token[:7], "bearer ") {
+		token = strings.TrimSpace(token[7:])
+	}
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return JWT{}, errors.New("jwt: expected three segments")
+	}
+
+	jwt := JWT{}
+	for i, target := range []*map[string]any{&jwt.Header, &jwt.Claims} {
+		data, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(parts[i], "="))
+		if err != nil {
+			return JWT{}, fmt.Errorf("jwt: %w", err)
+		}
+		if err := json.Unmarshal(data, target); err != nil {
+			return JWT{}, fmt.Errorf("jwt: %w", err)
+		}
+	}
+	return jwt, nil
+}
+
+// numericClaim returns a NumericDate claim such as exp or iat.
+func (j JWT) numericClaim(name string) (time.Time, bool) {
+	v, ok := j.Claims[name].(float64)
+	if !ok {
+		return time.Time{}, false
+	}
+	return time.Unix(int64(v), 0), true
+}
+
+// analyzeJWT reports weaknesses of a token found in a header, cookie or JSON
+// body. name is the cookie or JSON field holding the token, if any. The value
+// may be URL-encoded, as is common in cookies.
+func (s *HeaderScanner) analyzeJWT(value, header, name string, now time.Time, findings *[]Finding) {
+	if unescaped, err := url.QueryUnescape(value); err == nil {
+		value = unescaped
+	}
+	jwt, err := DecodeJWT(value)
+	if err != nil {
+		return
+	}
+
+	report := func(checkName, detail string) {
+		rule := rules.FindRule(rules.JWTRules, checkName)
+		finding := s.createFinding(rule, "misconfigured", rule.Risk)
+		finding.Header = header
+		source := header
+		switch {
+		case header == "Set-Cookie":
+			finding.Cookie = name
+			source = "cookie " + name
+		case name != "":
+			source = header + " field " + name
+		}
+		finding.Description += " (" + source
+		if detail != "" {
+			finding.Description += ": " + detail
+		}
+		finding.Description += ")"
+		*findings = append(*findings, finding)
+	}
+
+	if alg, _ := jwt.Header["alg"].(string); strings.EqualFold(alg, "none") {
+		report("JWT alg none", "")
+	}
+
+	exp, hasExp := jwt.numericClaim("exp")
+	if !hasExp {
+		report("JWT Missing exp", "")
+	} else {
+		start := now
+		if iat, ok := jwt.numericClaim("iat"); ok {
+			start = iat
+		}
+		if lifetime := exp.Sub(start); lifetime > jwtMaxLifetime {
+			report("JWT Long-Lived", fmt.Sprintf("valid for %d days", int(lifetime.Hours()/24)))
+		}
+	}
+
+	sensitive := []string{}
+	for name := range jwt.Claims {
+		if jwtSensitiveClaims[strings.ToLower(name)] {
+			sensitive = append(sensitive, name)
+		}
+	}
+	if len(sensitive) > 0 {
+		sort.Strings(sensitive)
+		report("JWT Sensitive Claims", "claims "+strings.Join(sensitive, ", "))
+	}
+}
+
+// tokenResponseHeaders lists response headers that commonly hand out access tokens.
+var tokenResponseHeaders = []string{"X-Auth-Token", "X-Access-Token", "X-Id-Token", "X-Refresh-Token", "Access-Token"}
+
+// analyzeJSONTokens reports weaknesses of JWTs issued in the string fields of
+// a JSON body, such as an OAuth token response.
+func (s *HeaderScanner) analyzeJSONTokens(body []byte, now time.Time, findings *[]Finding) {
+	var doc any
+	if err := json.Unmarshal(body, &doc); err != nil {
+		return
+	}
+
+	var walk func(name string, v any)
+	walk = func(name string, v any) {
+		switch v := v.(type) {
+		case string:
+			if looksLikeJWT(v) {
+				s.analyzeJWT(v, "Body", name, now, findings)
+			}
+		case []any:
+			for _, item := range v {
+				walk(name, item)
+			}
+		case map[string]any:
+			keys := make([]string, 0, len(v))
+			for key := range v {
+				keys = append(keys, key)
+			}
+			sort.Strings(keys)
+			for _, key := range keys {
+				if name != "" {
+					walk(name+"."+key, v[key])
+				} else {
+					walk(key, v[key])
+				}
+			}
+		}
+	}
+	walk("", doc)
+}
@@ -0,0 +1,161 @@
+package scanner
+
+import (
+	"bytes"
+	"html"
+	"mime"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/ismailtsdln/HeaderSentinel/internal/rules"
+)
+
+// maxBodyLen bounds how much of an HTML body is read for analysis.
+const maxBodyLen = 1 << 20
+
+// cspMetaIgnored lists the directives browsers ignore in a <meta> delivered policy.
+var cspMetaIgnored = []string{"frame-ancestors", "report-uri", "report-to", "sandbox"}
+
+var (
+	metaTagPattern   = regexp.MustCompile(`(?is)<meta\s[^>]*>`)
+	attributePattern = regexp.MustCompile(`(?s)([a-zA-Z_:][-a-zA-Z0-9_:.]*)\s*=\s*(?:"([^"]*)"|'([^']*)'|([^\s"'>]+))`)
+)
+
+// MetaPolicies holds the policies an HTML document delivers through <meta> tags.
+type MetaPolicies struct {
+	CSP            []string
+	ReferrerPolicy string
+	Refresh        string
+}
+
+// readHTMLBody returns up to maxBodyLen bytes of an HTML response body, or nil
+// for other content types. The body stays readable for later consumers.
+func readHTMLBody(resp *http.Response) []byte {
+	mediaType, _, _ := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+	if contentFamily(mediaType) != "html" {
+		return nil
+	}
+	return peekBody(resp, maxBodyLen)
+}
+
+// htmlAttributes parses the attributes of a start tag into a map keyed by
+// lower-case attribute name. Character references in values are decoded.
+func htmlAttributes(tag string) map[string]string {
+	attrs := map[string]string{}
+	for _, m := range attributePattern.FindAllStringSubmatch(tag, -1) {
+		name := strings.ToLower(m[1])
+		if _, ok := attrs[name]; !ok {
+			attrs[name] = html.UnescapeString(m[2] + m[3] + m[4])
+		}
+	}
+	return attrs
+}
+
+// ParseMetaPolicies extracts CSP, referrer policy and refresh <meta> tags.
+// Only the document head is searched, as browsers ignore a CSP <meta> elsewhere.
+func ParseMetaPolicies(body []byte) MetaPolicies {
+	lower := bytes.ToLower(body)
+	for _, end := range [][]byte{[]byte("</head"), []byte("<body")} {
+		if i := bytes.Index(lower, end); i >= 0 {
+			body, lower = body[:i], lower[:i]
+		}
+	}
+
+	policies := MetaPolicies{}
+	for _, tag := range metaTagPattern.FindAll(body, -1) {
+		attrs := htmlAttributes(string(tag))
+		content := strings.TrimSpace(attrs["content"])
+		switch {
+		case strings.EqualFold(attrs["http-equiv"], "content-security-policy"):
+			policies.CSP = append(policies.CSP, content)
+		case strings.EqualFold(attrs["http-equiv"], "refresh") && policies.Refresh == "":
+			policies.Refresh = content
+		case strings.EqualFold(attrs["name"], "referrer"):
+			// The last referrer <meta> wins
+			policies.ReferrerPolicy = content
+		}
+	}
+	return policies
+}
+
+// refreshTarget returns the URL of a refresh value such as "0; url=https://example.com/".
+func refreshTarget(value string) string {
+	_, rest, ok := strings.Cut(value, ";")
+	if !ok {
+		_, rest, ok = strings.Cut(value, ",")
+	}
+	if !ok {
+		return ""
+	}
+	rest = strings.TrimSpace(rest)
+	if len(rest) >= 4 && strings.EqualFold(rest[:3], "url") {
+		rest = strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(rest[3:]), "="))
+	}
+	return strings.Trim(rest, "'\"")
+}
+
+// markMeta notes on every finding added since start that it came from a <meta> tag.
+func markMeta(findings *[]Finding, start int) {
+	for i := start; i < len(*findings); i++ {
+		(*findings)[i].Description += " (delivered via <meta> tag)"
+	}
+}
+
+// analyzeMetaCSP evaluates policies delivered via <meta> together with the
+// header policies, as browsers enforce every one of them. Findings are marked
+// as coming from <meta> when no header policy is set.
+func (s *HeaderScanner) analyzeMetaCSP(headerValues, metaValues []string, findings *[]Finding) {
+	rule := rules.FindRule(rules.MetaRules, "CSP Delivered via Meta")
+	finding := s.createFinding(rule, "meta", rule.Risk)
+	if len(headerValues) > 0 {
+		finding.Description += " (enforced together with the Content-Security-Policy header)"
+	}
+	*findings = append(*findings, finding)
+
+	start := len(*findings)
+	policies := append(append([]string{}, headerValues...), metaValues...)
+	s.analyzeCSP(strings.Join(policies, ","), false, findings)
+	if len(headerValues) == 0 {
+		markMeta(findings, start)
+	}
+}
+
+// analyzeMetaIgnoredDirectives reports CSP directives that have no effect in <meta>.
+func (s *HeaderScanner) analyzeMetaIgnoredDirectives(values []string, findings *[]Finding) {
+	rule := rules.FindRule(rules.MetaRules, "CSP Directive Ignored in Meta")
+	for _, policy := range ParseCSP(strings.Join(values, ",")) {
+		for _, name := range cspMetaIgnored {
+			if policy.Has(name) {
+				finding := s.createFinding(rule, "misconfigured", rule.Risk)
+				finding.Description += " (" + name + ")"
+				*findings = append(*findings, finding)
+			}
+		}
+	}
+}
+
+func (s *HeaderScanner) analyzeMetaRefresh(resp *http.Response, value string, findings *[]Finding) {
+	target := refreshTarget(value)
+	if target == "" {
+		return
+	}
+	if resp.Request != nil && resp.Request.URL != nil {
+		if u, err := url.Parse(target); err == nil {
+			target = resp.Request.URL.ResolveReference(u).String()
+		}
+	}
+
+	checkName := "Meta Refresh Redirect"
+	if resp.Request != nil && resp.Request.URL != nil && resp.Request.URL.Scheme == "https" && strings.HasPrefix(strings.ToLower(target), "http://") {
+		checkName = "Insecure Meta Refresh"
+	}
+	rule := rules.FindRule(rules.MetaRules, checkName)
+	finding := s.createFinding(rule, "meta", rule.Risk)
+	if rule.Risk != rules.RiskInfo {
+		finding.Status = "misconfigured"
+	}
+	finding.Description += " (redirects to " + target + ")"
+	*findings = append(*findings, finding)
+}
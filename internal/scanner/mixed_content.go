@@ -0,0 +1,107 @@
+package scanner
+
+import (
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/ismailtsdln/HeaderSentinel/internal/rules"
+)
+
+var mixedContentTagPattern = regexp.MustCompile(`(?is)<(script|link|iframe|frame|object|embed|img|audio|video|source|track|form)\s[^>]*>`)
+
+// MixedContent is an http:// reference found in an HTTPS page.
+type MixedContent struct {
+	Tag  string
+	URL  string
+	Kind string // active / passive / form
+}
+
+// ExtractMixedContent returns the plain HTTP references of an HTML document,
+// classified as active content, passive content or form actions.
+func ExtractMixedContent(body []byte, base *url.URL) []MixedContent {
+	found := []MixedContent{}
+	for _, m := range mixedContentTagPattern.FindAllSubmatch(body, -1) {
+		tag := strings.ToLower(string(m[1]))
+		attrs := htmlAttributes(string(m[0]))
+
+		attr, kind := "src", "passive"
+		switch tag {
+		case "script", "iframe", "frame", "embed":
+			kind = "active"
+		case "object":
+			attr, kind = "data", "active"
+		case "form":
+			attr, kind = "action", "form"
+		case "link":
+			attr = "href"
+			rel := strings.Fields(strings.ToLower(attrs["rel"]))
+			switch {
+			case containsSource(rel, "stylesheet"):
+				kind = "active"
+			case !containsSource(rel, "icon"):
+				continue
+			}
+		}
+
+		ref := strings.TrimSpace(attrs[attr])
+		if ref == "" {
+			continue
+		}
+		u, err := url.Parse(ref)
+		if err != nil {
+			continue
+		}
+		if base != nil {
+			u = base.ResolveReference(u)
+		}
+		if u.Scheme == "http" {
+			found = append(found, MixedContent{Tag: tag, URL: u.String(), Kind: kind})
+		}
+	}
+	return found
+}
+
+// analyzeMixedContent reports plain HTTP references on an HTTPS page. A CSP
+// with upgrade-insecure-requests or block-all-mixed-content, delivered by
+// header or <meta>, mitigates them and lowers the findings to INFO.
+func (s *HeaderScanner) analyzeMixedContent(resp *http.Response, body []byte, metaCSP []string, findings *[]Finding) {
+	if len(body) == 0 || resp.Request == nil || resp.Request.URL == nil || resp.Request.URL.Scheme != "https" {
+		return
+	}
+
+	upgrade, block := false, false
+	policies := append([]string{}, resp.Header.Values("Content-Security-Policy")...)
+	for _, policy := range ParseCSP(strings.Join(append(policies, metaCSP...), ",")) {
+		upgrade = upgrade || policy.Has("upgrade-insecure-requests")
+		block = block || policy.Has("block-all-mixed-content")
+	}
+
+	for _, mc := range ExtractMixedContent(body, resp.Request.URL) {
+		checkName := "Passive Mixed Content"
+		switch mc.Kind {
+		case "active":
+			checkName = "Active Mixed Content"
+		case "form":
+			checkName = "Insecure Form Action"
+		}
+		rule := rules.FindRule(rules.HTMLRules, checkName)
+		finding := s.createFinding(rule, "misconfigured", rule.Risk)
+		finding.Description += " (<" + mc.Tag + "> " + mc.URL
+
+		// block-all-mixed-content blocks subresources but not form submissions
+		switch {
+		case upgrade:
+			finding.Status = "mitigated"
+			finding.Risk = rules.RiskInfo
+			finding.Description += ", upgraded by upgrade-insecure-requests"
+		case block && mc.Kind != "form":
+			finding.Status = "mitigated"
+			finding.Risk = rules.RiskInfo
+			finding.Description += ", blocked by block-all-mixed-content"
+		}
+		finding.Description += ")"
+		*findings = append(*findings, finding)
+	}
+}
@@ -0,0 +1,80 @@
+package scanner
+
+import (
+	"strings"
+
+	"github.com/ismailtsdln/HeaderSentinel/internal/rules"
+)
+
+// ParsePermissionsPolicy parses a Permissions-Policy header into a map of
+// feature name to allowlist. Allowlist entries are tokens such as "*" and
+// "self", or origins taken from quoted strings.
+func ParsePermissionsPolicy(value string) (map[string][]string, error) {
+	members, err := ParseSFDictionary(value)
+	if err != nil {
+		return nil, err
+	}
+	policy := map[string][]string{}
+	for _, member := range members {
+		allowlist := []string{}
+		for _, item := range member.Items {
+			allowlist = append(allowlist, item.Value)
+		}
+		policy[member.Key] = allowlist
+	}
+	return policy, nil
+}
+
+// isLegacyFeaturePolicy reports whether a value uses the Feature-Policy syntax
+// of space-separated allowlists and semicolon-separated directives.
+func isLegacyFeaturePolicy(value string) bool {
+	for _, directive := range strings.Split(value, ";") {
+		fields := strings.Fields(directive)
+		if len(fields) >= 2 && !strings.Contains(fields[0], "=") {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *HeaderScanner) analyzePermissionsPolicy(value string, findings *[]Finding) {
+	report := func(checkName, detail string) {
+		rule := rules.FindRule(rules.PermissionsPolicyRules, checkName)
+		finding := s.createFinding(rule, "misconfigured", rule.Risk)
+		if detail != "" {
+			finding.Description += " (" + detail + ")"
+		}
+		*findings = append(*findings, finding)
+	}
+
+	policy, err := ParsePermissionsPolicy(value)
+	if err != nil {
+		if isLegacyFeaturePolicy(value) {
+			report("Legacy Feature-Policy Syntax", "")
+		} else {
+			report("Invalid Permissions-Policy", err.Error())
+		}
+		return
+	}
+
+	defaults := []string{}
+	for _, feature := range rules.PowerfulFeatures {
+		allowlist, ok := policy[feature]
+		if !ok {
+			defaults = append(defaults, feature)
+			continue
+		}
+		if containsSource(allowlist, "*") {
+			report("Powerful Feature Allowed to All Origins", feature+"=*")
+		}
+	}
+	if len(defaults) > 0 {
+		report("Powerful Feature Left at Default", strings.Join(defaults, ", "))
+	}
+
+	for _, feature := range s.DisabledFeatures {
+		if allowlist, ok := policy[feature]; !ok || len(allowlist) > 0 {
+			report("Required Feature Not Disabled", feature)
+		}
+	}
+}
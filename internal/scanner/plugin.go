@@ -0,0 +1,158 @@
+package scanner
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/ismailtsdln/HeaderSentinel/internal/rules"
+)
+
+// maxPluginOutput bounds how much plugin output is kept.
+const maxPluginOutput = 1 << 20
+
+// PluginInput is the JSON document written to a plugin's stdin.
+type PluginInput struct {
+	URL       string              `json:"url"`
+	Status    int                 `json:"status"`
+	Headers   map[string][]string `json:"headers"`
+	Redirects []RedirectHop       `json:"redirects"`
+}
+
+// PluginOutput is the JSON document a plugin writes to stdout. Findings use
+// the field names of the JSON report, e.g. {"Header": "X-Api-Key", "Risk": "HIGH"}.
+type PluginOutput struct {
+	Findings []Finding `json:"findings"`
+}
+
+// Plugin is a Check backed by an external executable. It receives a
+// PluginInput on stdin and must exit 0 after writing a PluginOutput.
+type Plugin struct {
+	Path    string
+	Args    []string
+	Timeout time.Duration
+}
+
+// Name returns the check name of the plugin. It uses the full path, so
+// plugins with the same file name in different directories do not replace
+// each other.
+func (p *Plugin) Name() string {
+	return "plugin:" + p.Path
+}
+
+// Run executes the plugin for a target. A failing plugin never aborts the
+// scan; the failure is reported as an informational finding instead.
+func (p *Plugin) Run(t *Target) []Finding {
+	findings, err := p.run(t)
+	if err != nil {
+		rule := rules.FindRule(rules.PluginRules, "Plugin Failure")
+		finding := newFinding(rule, "error", rule.Risk)
+		finding.Description += " (" + p.Name() + ": " + err.Error() + ")"
+		return []Finding{finding}
+	}
+	return findings
+}
+
+func (p *Plugin) run(t *Target) (findings []Finding, err error) {
+	// A panic while decoding or normalizing is reported like any other plugin failure
+	defer func() {
+		if r := recover(); r != nil {
+			findings, err = nil, fmt.Errorf("panic: %v", r)
+		}
+	}()
+
+	input, err := json.Marshal(newPluginInput(t))
+	if err != nil {
+		return nil, err
+	}
+
+	ctx := context.Background()
+	if p.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, p.Timeout)
+		defer cancel()
+	}
+	cmd := exec.CommandContext(ctx, p.Path, p.Args...)
+	cmd.Stdin = bytes.NewReader(input)
+	stdout, stderr := &limitedBuffer{max: maxPluginOutput}, &limitedBuffer{max: 4096}
+	cmd.Stdout, cmd.Stderr = stdout, stderr
+	// Do not wait forever for grandchildren that inherited the output pipes
+	cmd.WaitDelay = time.Second
+
+	if err := cmd.Run(); err != nil {
+		if ctx.Err() == context.DeadlineExceeded {
+			return nil, fmt.Errorf("timed out after %s", p.Timeout)
+		}
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return nil, fmt.Errorf("%v: %s", err, msg)
+		}
+		return nil, err
+	}
+	if stdout.truncated {
+		return nil, fmt.Errorf("output exceeds %d bytes", maxPluginOutput)
+	}
+
+	output := PluginOutput{}
+	if err := json.Unmarshal(stdout.Bytes(), &output); err != nil {
+		return nil, fmt.Errorf("invalid output: %w", err)
+	}
+	for i := range output.Findings {
+		normalizePluginFinding(&output.Findings[i], p.Name())
+	}
+	return output.Findings, nil
+}
+
+func newPluginInput(t *Target) PluginInput {
+	input := PluginInput{
+		Status:    t.Response.StatusCode,
+		Headers:   t.Response.Header,
+		Redirects: t.Redirects.Chain,
+	}
+	if t.Request != nil && t.Request.URL != nil {
+		input.URL = t.Request.URL.String()
+	}
+	if input.Redirects == nil {
+		input.Redirects = []RedirectHop{}
+	}
+	return input
+}
+
+// normalizePluginFinding fills in defaults and upper-cases the risk level so
+// plugin findings score and render like built-in ones.
+func normalizePluginFinding(f *Finding, name string) {
+	if f.RuleID == "" {
+		f.RuleID = name
+	}
+	if f.Header == "" {
+		f.Header = name
+	}
+	if f.Status == "" {
+		f.Status = "misconfigured"
+	}
+	f.Risk = rules.RiskLevel(strings.ToUpper(string(f.Risk)))
+	switch f.Risk {
+	case rules.RiskCritical, rules.RiskHigh, rules.RiskMedium, rules.RiskLow, rules.RiskInfo:
+	default:
+		f.Risk = rules.RiskMedium
+	}
+}
+
+// limitedBuffer keeps the first max bytes written to it and discards the rest.
+type limitedBuffer struct {
+	bytes.Buffer
+	max       int
+	truncated bool
+}
+
+func (b *limitedBuffer) Write(p []byte) (int, error) {
+	if room := b.max - b.Len(); len(p) > room {
+		b.Buffer.Write(p[:max(room, 0)])
+		b.truncated = true
+		return len(p), nil
+	}
+	return b.Buffer.Write(p)
+}
@@ -0,0 +1,58 @@
+package scanner
+
+import (
+	"strings"
+
+	"github.com/ismailtsdln/HeaderSentinel/internal/rules"
+)
+
+// referrerPolicyClasses maps each policy token defined by the Referrer Policy
+// specification to its safety class.
+var referrerPolicyClasses = map[string]string{
+	"no-referrer":                     "safe",
+	"same-origin":                     "safe",
+	"strict-origin":                   "safe",
+	"strict-origin-when-cross-origin": "safe",
+	"origin":                          "weak",
+	"origin-when-cross-origin":        "weak",
+	"no-referrer-when-downgrade":      "weak",
+	"unsafe-url":                      "unsafe",
+}
+
+// EffectiveReferrerPolicy returns the policy browsers apply for a
+// Referrer-Policy value. The value is a fallback list, so the last recognised
+// token wins and unknown tokens are skipped. It returns an empty string when
+// no token is recognised.
+func EffectiveReferrerPolicy(value string) string {
+	policy := ""
+	for _, token := range strings.Split(value, ",") {
+		token = strings.ToLower(strings.TrimSpace(token))
+		if _, ok := referrerPolicyClasses[token]; ok {
+			policy = token
+		}
+	}
+	return policy
+}
+
+func (s *HeaderScanner) analyzeReferrerPolicy(value string, findings *[]Finding) {
+	policy := EffectiveReferrerPolicy(value)
+
+	checkName := ""
+	switch referrerPolicyClasses[policy] {
+	case "safe":
+		return
+	case "weak":
+		checkName = "Weak Referrer Policy"
+	case "unsafe":
+		checkName = "Unsafe Referrer Policy"
+	default:
+		checkName = "Unrecognised Referrer Policy"
+	}
+
+	rule := rules.FindRule(rules.ReferrerPolicyRules, checkName)
+	finding := s.createFinding(rule, "misconfigured", rule.Risk)
+	if policy != "" {
+		finding.Description += " (effective policy " + policy + ")"
+	}
+	*findings = append(*findings, finding)
+}
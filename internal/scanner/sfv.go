@@ -0,0 +1,274 @@
+package scanner
+
+import (
+	"fmt"
+	"strings"
+)
+
+// SFItem is a bare item of an RFC 8941 structured field. Tokens, strings,
+// numbers and booleans are all kept as their textual form; IsString tells
+// quoted strings apart from tokens.
+type SFItem struct {
+	Value    string
+	IsString bool
+}
+
+// SFMember is a dictionary member. A member is either a single item or an
+// inner list; a member without a value is the boolean true.
+type SFMember struct {
+	Key       string
+	Items     []SFItem
+	InnerList bool
+}
+
+// ParseSFDictionary parses an RFC 8941 dictionary. Parameters are parsed for
+// validity but not returned. Later duplicate keys override earlier ones, as
+// the RFC requires.
+func ParseSFDictionary(value string) ([]SFMember, error) {
+	p := &sfParser{s: value}
+	members := []SFMember{}
+	index := map[string]int{}
+
+	p.skipOWS()
+	if p.done() {
+		return members, nil
+	}
+	for {
+		member, err := p.member()
+		if err != nil {
+			return nil, err
+		}
+		if i, ok := index[member.Key]; ok {
+			members[i] = member
+		} else {
+			index[member.Key] = len(members)
+			members = append(members, member)
+		}
+
+		p.skipOWS()
+		if p.done() {
+			return members, nil
+		}
+		if p.s[p.i] != ',' {
+			return nil, p.errorf("expected ','")
+		}
+		p.i++
+		p.skipOWS()
+		if p.done() {
+			return nil, p.errorf("trailing ','")
+		}
+	}
+}
+
+type sfParser struct {
+	s string
+	i int
+}
+
+func (p *sfParser) done() bool { return p.i >= len(p.s) }
+
+func (p *sfParser) errorf(format string, args ...any) error {
+	return fmt.Errorf("structured field: "+format+" at offset %d", append(args, p.i)...)
+}
+
+func (p *sfParser) skipOWS() {
+	for !p.done() && (p.s[p.i] == ' ' || p.s[p.i] == '\t') {
+		p.i++
+	}
+}
+
+func (p *sfParser) skipSP() {
+	for !p.done() && p.s[p.i] == ' ' {
+		p.i++
+	}
+}
+
+func (p *sfParser) member() (SFMember, error) {
+	key, err := p.key()
+	if err != nil {
+		return SFMember{}, err
+	}
+	member := SFMember{Key: key}
+	if p.done() || p.s[p.i] != '=' {
+		member.Items = []SFItem{{Value: "?1"}}
+		return member, p.parameters()
+	}
+	p.i++
+
+	if !p.done() && p.s[p.i] == '(' {
+		member.InnerList = true
+		member.Items, err = p.innerList()
+		return member, err
+	}
+	item, err := p.bareItem()
+	if err != nil {
+		return SFMember{}, err
+	}
+	member.Items = []SFItem{item}
+	return member, p.parameters()
+}
+
+func (p *sfParser) key() (string, error) {
+	start := p.i
+	if p.done() || !(isLCAlpha(p.s[p.i]) || p.s[p.i] == '*') {
+		return "", p.errorf("invalid key")
+	}
+	for !p.done() && (isLCAlpha(p.s[p.i]) || isDigit(p.s[p.i]) || strings.IndexByte("_-.*", p.s[p.i]) >= 0) {
+		p.i++
+	}
+	return p.s[start:p.i], nil
+}
+
+func (p *sfParser) innerList() ([]SFItem, error) {
+	p.i++ // consume '('
+	items := []SFItem{}
+	for {
+		p.skipSP()
+		if p.done() {
+			return nil, p.errorf("unterminated inner list")
+		}
+		if p.s[p.i] == ')' {
+			p.i++
+			return items, p.parameters()
+		}
+		item, err := p.bareItem()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.parameters(); err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+		if !p.done() && p.s[p.i] != ' ' && p.s[p.i] != ')' {
+			return nil, p.errorf("expected ' ' or ')'")
+		}
+	}
+}
+
+func (p *sfParser) parameters() error {
+	for !p.done() && p.s[p.i] == ';' {
+		p.i++
+		p.skipSP()
+		if _, err := p.key(); err != nil {
+			return err
+		}
+		if !p.done() && p.s[p.i] == '=' {
+			p.i++
+			if _, err := p.bareItem(); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (p *sfParser) bareItem() (SFItem, error) {
+	if p.done() {
+		return SFItem{}, p.errorf("expected item")
+	}
+	start := p.i
+	c := p.s[p.i]
+	switch {
+	case c == '"':
+		return p.str()
+	case c == '-' || isDigit(c):
+		return p.number()
+	case c == '?':
+		p.i++
+		if p.done() || (p.s[p.i] != '0' && p.s[p.i] != '1') {
+			return SFItem{}, p.errorf("invalid boolean")
+		}
+		p.i++
+	case c == ':':
+		end := strings.IndexByte(p.s[p.i+1:], ':')
+		if end < 0 {
+			return SFItem{}, p.errorf("unterminated byte sequence")
+		}
+		for _, b := range []byte(p.s[p.i+1 : p.i+1+end]) {
+			if !isAlpha(b) && !isDigit(b) && b != '+' && b != '/' && b != '=' {
+				return SFItem{}, p.errorf("invalid base64 character %q in byte sequence", b)
+			}
+		}
+		p.i += end + 2
+	case isAlpha(c) || c == '*':
+		p.i++
+		for !p.done() && (isTChar(p.s[p.i]) || p.s[p.i] == ':' || p.s[p.i] == '/') {
+			p.i++
+		}
+	default:
+		return SFItem{}, p.errorf("unexpected %q", c)
+	}
+	return SFItem{Value: p.s[start:p.i]}, nil
+}
+
+// number parses an integer of at most 15 digits, or a decimal with at most
+// 12 integer and 1 to 3 fraction digits.
+func (p *sfParser) number() (SFItem, error) {
+	start := p.i
+	if p.s[p.i] == '-' {
+		p.i++
+	}
+	intDigits, fracDigits, decimal := 0, 0, false
+	for ; !p.done(); p.i++ {
+		c := p.s[p.i]
+		if c == '.' && !decimal && intDigits > 0 {
+			decimal = true
+			continue
+		}
+		if !isDigit(c) {
+			break
+		}
+		if decimal {
+			fracDigits++
+		} else {
+			intDigits++
+		}
+	}
+
+	switch {
+	case intDigits == 0:
+		return SFItem{}, p.errorf("expected digit")
+	case !decimal && intDigits > 15:
+		return SFItem{}, p.errorf("integer has more than 15 digits")
+	case decimal && intDigits > 12:
+		return SFItem{}, p.errorf("decimal has more than 12 integer digits")
+	case decimal && fracDigits == 0:
+		return SFItem{}, p.errorf("decimal has no fraction digits")
+	case decimal && fracDigits > 3:
+		return SFItem{}, p.errorf("decimal has more than 3 fraction digits")
+	}
+	return SFItem{Value: p.s[start:p.i]}, nil
+}
+
+func (p *sfParser) str() (SFItem, error) {
+	p.i++ // consume opening quote
+	var b strings.Builder
+	for !p.done() {
+		c := p.s[p.i]
+		p.i++
+		switch {
+		case c == '\\':
+			if p.done() || (p.s[p.i] != '"' && p.s[p.i] != '\\') {
+				return SFItem{}, p.errorf("invalid escape")
+			}
+			b.WriteByte(p.s[p.i])
+			p.i++
+		case c == '"':
+			return SFItem{Value: b.String(), IsString: true}, nil
+		case c < 0x20 || c > 0x7e:
+			return SFItem{}, p.errorf("invalid character in string")
+		default:
+			b.WriteByte(c)
+		}
+	}
+	return SFItem{}, p.errorf("unterminated string")
+}
+
+func isLCAlpha(c byte) bool { return c >= 'a' && c <= 'z' }
+func isAlpha(c byte) bool   { return isLCAlpha(c) || (c >= 'A' && c <= 'Z') }
+func isDigit(c byte) bool   { return c >= '0' && c <= '9' }
+
+// isTChar reports whether c is an HTTP token character (RFC 9110).
+func isTChar(c byte) bool {
+	return isAlpha(c) || isDigit(c) || strings.IndexByte("!#$%&'*+-.^_`|~", c) >= 0
+}
@@ -0,0 +1,136 @@
+package scanner
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseSFDictionary(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+		want  []SFMember
+	}{
+		{
+			name:  "empty",
+			value: "  ",
+			want:  []SFMember{},
+		},
+		{
+			name:  "tokens, strings and numbers",
+			value: `a=tok, b="quoted \"s\"", c=-1.5`,
+			want: []SFMember{
+				{Key: "a", Items: []SFItem{{Value: "tok"}}},
+				{Key: "b", Items: []SFItem{{Value: `quoted "s"`, IsString: true}}},
+				{Key: "c", Items: []SFItem{{Value: "-1.5"}}},
+			},
+		},
+		{
+			name:  "booleans",
+			value: "a, b=?0, c=?1",
+			want: []SFMember{
+				{Key: "a", Items: []SFItem{{Value: "?1"}}},
+				{Key: "b", Items: []SFItem{{Value: "?0"}}},
+				{Key: "c", Items: []SFItem{{Value: "?1"}}},
+			},
+		},
+		{
+			name:  "inner lists",
+			value: `geolocation=(self "https://a.example"), camera=(), fullscreen=*`,
+			want: []SFMember{
+				{Key: "geolocation", Items: []SFItem{{Value: "self"}, {Value: "https://a.example", IsString: true}}, InnerList: true},
+				{Key: "camera", Items: []SFItem{}, InnerList: true},
+				{Key: "fullscreen", Items: []SFItem{{Value: "*"}}},
+			},
+		},
+		{
+			name:  "parameters are skipped",
+			value: `a=1;q=0.5, b=(x;p y);lvl=?1, c;flag`,
+			want: []SFMember{
+				{Key: "a", Items: []SFItem{{Value: "1"}}},
+				{Key: "b", Items: []SFItem{{Value: "x"}, {Value: "y"}}, InnerList: true},
+				{Key: "c", Items: []SFItem{{Value: "?1"}}},
+			},
+		},
+		{
+			name:  "number limits",
+			value: "a=123456789012345, b=-123456789012.123, c=0.5, d=-0",
+			want: []SFMember{
+				{Key: "a", Items: []SFItem{{Value: "123456789012345"}}},
+				{Key: "b", Items: []SFItem{{Value: "-123456789012.123"}}},
+				{Key: "c", Items: []SFItem{{Value: "0.5"}}},
+				{Key: "d", Items: []SFItem{{Value: "-0"}}},
+			},
+		},
+		{
+			name:  "byte sequence",
+			value: "a=:aGVsbG8=:",
+			want:  []SFMember{{Key: "a", Items: []SFItem{{Value: ":aGVsbG8=:"}}}},
+		},
+		{
+			name:  "duplicate key overrides in place",
+			value: "a=1, b=2, a=(3)",
+			want: []SFMember{
+				{Key: "a", Items: []SFItem{{Value: "3"}}, InnerList: true},
+				{Key: "b", Items: []SFItem{{Value: "2"}}},
+			},
+		},
+		{
+			name:  "tabs and spaces around members",
+			value: "\ta=1 ,\tb=2 ",
+			want: []SFMember{
+				{Key: "a", Items: []SFItem{{Value: "1"}}},
+				{Key: "b", Items: []SFItem{{Value: "2"}}},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseSFDictionary(tt.value)
+			if err != nil {
+				t.Fatalf("ParseSFDictionary(%q): %v", tt.value, err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseSFDictionary(%q) = %#v, want %#v", tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseSFDictionaryErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+	}{
+		{"trailing comma", "a=1,"},
+		{"trailing comma and space", "a=1, "},
+		{"leading comma", ",a=1"},
+		{"missing comma", "a=1 b=2"},
+		{"upper-case key", "A=1"},
+		{"invalid boolean", "a=?2"},
+		{"unterminated inner list", "a=(x y"},
+		{"inner list without space", `a=(x"y")`},
+		{"unterminated string", `a="x`},
+		{"invalid escape", `a="\n"`},
+		{"unterminated byte sequence", "a=:abc"},
+		{"empty value", "a="},
+		{"invalid parameter key", "a=1;=2"},
+		{"minus without digits", "a=-"},
+		{"two decimal points", "a=1.2.3"},
+		{"decimal without fraction", "a=1.."},
+		{"trailing decimal point", "a=1."},
+		{"integer with 16 digits", "a=1234567890123456"},
+		{"integer with 19 digits", "a=1234567890123456789"},
+		{"decimal with 13 integer digits", "a=1234567890123.5"},
+		{"decimal with 4 fraction digits", "a=1.2345"},
+		{"parameter number without digits", "a=1;q=-"},
+		{"non-base64 byte sequence", "a=:!!:"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got, err := ParseSFDictionary(tt.value); err == nil {
+				t.Errorf("ParseSFDictionary(%q) = %#v, want error", tt.value, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,107 @@
+package scanner
+
+import (
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/ismailtsdln/HeaderSentinel/internal/rules"
+)
+
+var (
+	scriptTagPattern = regexp.MustCompile(`(?is)<script\s[^>]*>`)
+	linkTagPattern   = regexp.MustCompile(`(?is)<link\s[^>]*>`)
+)
+
+// Subresource is a script or stylesheet referenced by an HTML page.
+type Subresource struct {
+	Tag         string // script / link
+	URL         string // resolved against the page URL
+	Integrity   string
+	CrossOrigin bool // crossorigin attribute present
+}
+
+// ExtractSubresources returns the external scripts and stylesheets of an HTML
+// document, with URLs resolved against base.
+func ExtractSubresources(body []byte, base *url.URL) []Subresource {
+	resources := []Subresource{}
+	add := func(tag, ref string, attrs map[string]string) {
+		u, err := url.Parse(strings.TrimSpace(ref))
+		if err != nil {
+			return
+		}
+		if base != nil {
+			u = base.ResolveReference(u)
+		}
+		_, crossOrigin := attrs["crossorigin"]
+		resources = append(resources, Subresource{Tag: tag, URL: u.String(), Integrity: attrs["integrity"], CrossOrigin: crossOrigin})
+	}
+
+	for _, tag := range scriptTagPattern.FindAll(body, -1) {
+		attrs := htmlAttributes(string(tag))
+		if src := attrs["src"]; src != "" {
+			add("script", src, attrs)
+		}
+	}
+	for _, tag := range linkTagPattern.FindAll(body, -1) {
+		attrs := htmlAttributes(string(tag))
+		if href := attrs["href"]; href != "" && containsSource(strings.Fields(attrs["rel"]), "stylesheet") {
+			add("link", href, attrs)
+		}
+	}
+	return resources
+}
+
+// sameOrigin reports whether two URLs share scheme, host and port.
+func sameOrigin(a, b *url.URL) bool {
+	return strings.EqualFold(a.Scheme, b.Scheme) && strings.EqualFold(a.Host, b.Host)
+}
+
+// hasStrongSRIHash reports whether an integrity value contains a hash with an
+// algorithm browsers support.
+func hasStrongSRIHash(integrity string) bool {
+	for _, hash := range strings.Fields(integrity) {
+		lower := strings.ToLower(hash)
+		if strings.HasPrefix(lower, "sha256-") || strings.HasPrefix(lower, "sha384-") || strings.HasPrefix(lower, "sha512-") {
+			return true
+		}
+	}
+	return false
+}
+
+// analyzeSubresources audits Subresource Integrity of the cross-origin scripts
+// and stylesheets of an HTML page.
+func (s *HeaderScanner) analyzeSubresources(resp *http.Response, body []byte, findings *[]Finding) {
+	if len(body) == 0 || resp.Request == nil || resp.Request.URL == nil {
+		return
+	}
+	page := resp.Request.URL
+
+	report := func(checkName string, res Subresource) {
+		rule := rules.FindRule(rules.HTMLRules, checkName)
+		risk := rule.Risk
+		// Stylesheets cannot run script, a tampered one is less severe
+		if res.Tag == "link" && checkName != "SRI Missing crossorigin" {
+			risk = lowerRisk(risk)
+		}
+		finding := s.createFinding(rule, "misconfigured", risk)
+		finding.Description += " (<" + res.Tag + "> " + res.URL + ")"
+		*findings = append(*findings, finding)
+	}
+
+	for _, res := range ExtractSubresources(body, page) {
+		u, err := url.Parse(res.URL)
+		if err != nil || sameOrigin(u, page) || (u.Scheme != "http" && u.Scheme != "https") {
+			continue
+		}
+		switch {
+		case res.Integrity == "":
+			report("Missing Subresource Integrity", res)
+		case !hasStrongSRIHash(res.Integrity):
+			report("Weak SRI Hash", res)
+		case !res.CrossOrigin:
+			report("SRI Missing crossorigin", res)
+		}
+	}
+}
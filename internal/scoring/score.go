@@ -17,6 +17,12 @@ func CalculateScore(findings []scanner.Finding) ScoreResult {
 	score := 100
 
 	for _, f := range findings {
+		// A strict CSP offsets deductions elsewhere, but never lifts the score above 100
+		if f.Status == "strict" {
+			score += 10
+			continue
+		}
+
 		switch f.Risk {
 		case rules.RiskCritical:
 			score -= 40
@@ -32,6 +38,9 @@ func CalculateScore(findings []scanner.Finding) ScoreResult {
 	if score < 0 {
 		score = 0
 	}
+	if score > 100 {
+		score = 100
+	}
 
 	risk := "Excellent"
 	switch {
@@ -3,6 +3,7 @@ package utils
 import (
 	"crypto/tls"
 	"net/http"
+	"strings"
 	"time"
 )
 
@@ -41,3 +42,30 @@ func (c *HTTPClient) Get(url string) (*http.Response, error) {
 
 	return c.Client.Do(req)
 }
+
+// SetHeaders adds header to every request sent to one of hosts, such as
+// credentials for an authenticated scan. Requests redirected to other hosts
+// are sent without them.
+func (c *HTTPClient) SetHeaders(header http.Header, hosts []string) {
+	c.Client.Transport = &headerTransport{base: c.Client.Transport, header: header, hosts: hosts}
+}
+
+type headerTransport struct {
+	base   http.RoundTripper
+	header http.Header
+	hosts  []string
+}
+
+func (t *headerTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	for _, host := range t.hosts {
+		if strings.EqualFold(req.URL.Hostname(), host) {
+			// A RoundTripper must not modify the caller's request
+			req = req.Clone(req.Context())
+			for name, values := range t.header {
+				req.Header[name] = values
+			}
+			break
+		}
+	}
+	return t.base.RoundTrip(req)
+}